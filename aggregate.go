@@ -0,0 +1,186 @@
+package urx
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// returned by Sum and Average when they are given a value which is not a number
+var ErrNotNumeric = errors.New("urx: value is not a number")
+
+// accumulates the values of a single subscription
+type aggregator struct {
+	add func(interface{}) error
+	// the aggregate, and whether there is one
+	result func() (interface{}, bool)
+}
+
+// emits the result of an aggregator, made for every subscription, once the source completes.
+// If the source (or the aggregator) errors, the error is passed on instead of the result
+func (o bObservable) aggregate(newAgg func() aggregator) Observable {
+	return o.LiftFunc(func() Operator {
+		agg := newAgg()
+		failed := false
+		return FunctionOperator(func(sub Subscriber, n Notification) {
+			switch n.Type {
+			case OnNext:
+				if failed {
+					return
+				}
+				if err := agg.add(n.Body); err != nil {
+					failed = true
+					sub.Notify(Error(err))
+					sub.Notify(Complete())
+				}
+				return
+			case OnError:
+				failed = true
+			case OnComplete:
+				if v, ok := agg.result(); ok && !failed {
+					sub.Notify(Next(v))
+				}
+			}
+			sub.Notify(n)
+		})
+	})
+}
+
+// emits the accumulator after each value, starting from seed
+func (o bObservable) Scan(seed interface{}, acc func(acc, value interface{}) interface{}) Observable {
+	return o.LiftFunc(func() Operator {
+		state := seed
+		return FunctionOperator(func(sub Subscriber, n Notification) {
+			if n.Type == OnNext {
+				state = acc(state, n.Body)
+				n.Body = state
+			}
+			sub.Notify(n)
+		})
+	})
+}
+
+// emits the final accumulator, starting from seed, once the source completes
+func (o bObservable) Reduce(seed interface{}, acc func(acc, value interface{}) interface{}) Observable {
+	return o.aggregate(func() aggregator {
+		state := seed
+		return aggregator{
+			add: func(v interface{}) error {
+				state = acc(state, v)
+				return nil
+			},
+			result: func() (interface{}, bool) {
+				return state, true
+			},
+		}
+	})
+}
+
+// emits how many values there were (an int) once the source completes
+func (o bObservable) Count() Observable {
+	return o.Reduce(0, func(acc, _ interface{}) interface{} {
+		return acc.(int) + 1
+	})
+}
+
+// emits the smallest value once the source completes, or nothing if there were no values
+func (o bObservable) Min(less func(a, b interface{}) bool) Observable {
+	return o.aggregate(func() aggregator {
+		return best(func(v, current interface{}) bool {
+			return less(v, current)
+		})
+	})
+}
+
+// emits the largest value once the source completes, or nothing if there were no values
+func (o bObservable) Max(less func(a, b interface{}) bool) Observable {
+	return o.aggregate(func() aggregator {
+		return best(func(v, current interface{}) bool {
+			return less(current, v)
+		})
+	})
+}
+
+// keeps whichever value better prefers, the earliest of equal values
+func best(better func(v, current interface{}) bool) aggregator {
+	var current interface{}
+	found := false
+	return aggregator{
+		add: func(v interface{}) error {
+			if !found || better(v, current) {
+				current = v
+				found = true
+			}
+			return nil
+		},
+		result: func() (interface{}, bool) {
+			return current, found
+		},
+	}
+}
+
+// emits the total of the values once the source completes, as the type of the first value (or 0 if
+// there were no values). Later values are converted to that type
+func (o bObservable) Sum() Observable {
+	return o.aggregate(func() aggregator {
+		var sum reflect.Value
+		return aggregator{
+			add: func(v interface{}) error {
+				val, err := number(v)
+				if err != nil {
+					return err
+				}
+				if !sum.IsValid() {
+					sum = reflect.New(val.Type()).Elem()
+				}
+				val = val.Convert(sum.Type())
+				switch {
+				case sum.CanInt():
+					sum.SetInt(sum.Int() + val.Int())
+				case sum.CanUint():
+					sum.SetUint(sum.Uint() + val.Uint())
+				default:
+					sum.SetFloat(sum.Float() + val.Float())
+				}
+				return nil
+			},
+			result: func() (interface{}, bool) {
+				if !sum.IsValid() {
+					return 0, true
+				}
+				return sum.Interface(), true
+			},
+		}
+	})
+}
+
+// emits the mean of the values as a float64 once the source completes, or nothing if there were no values
+func (o bObservable) Average() Observable {
+	return o.aggregate(func() aggregator {
+		var sum float64
+		count := 0
+		return aggregator{
+			add: func(v interface{}) error {
+				val, err := number(v)
+				if err != nil {
+					return err
+				}
+				sum += val.Convert(reflect.TypeOf(sum)).Float()
+				count++
+				return nil
+			},
+			result: func() (interface{}, bool) {
+				return sum / float64(count), count > 0
+			},
+		}
+	})
+}
+
+// reflects an integer or floating point value
+func number(v interface{}) (reflect.Value, error) {
+	val := reflect.ValueOf(v)
+	if k := val.Kind(); k < reflect.Int || k > reflect.Float64 {
+		return val, fmt.Errorf("%w: %T", ErrNotNumeric, v)
+	}
+	return val, nil
+}
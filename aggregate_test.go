@@ -0,0 +1,62 @@
+package urx
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestAggregates(t *testing.T) {
+	less := func(a, b interface{}) bool {
+		return a.(int) < b.(int)
+	}
+	add := func(acc, v interface{}) interface{} {
+		return acc.(int) + v.(int)
+	}
+	source := Just(3, 1, 4, 1, 5)
+	tests := []struct {
+		name     string
+		obs      Observable
+		expected []interface{}
+	}{
+		{"scan", source.Scan(0, add), []interface{}{3, 4, 8, 9, 14}},
+		{"reduce", source.Reduce(10, add), []interface{}{24}},
+		{"count", source.Count(), []interface{}{5}},
+		{"min", source.Min(less), []interface{}{1}},
+		{"max", source.Max(less), []interface{}{5}},
+		{"sum", source.Sum(), []interface{}{14}},
+		{"sum floats", Just(1.5, 2).Sum(), []interface{}{3.5}},
+		{"average", source.Average(), []interface{}{2.8}},
+		{"empty min", Empty().Min(less), nil},
+		{"empty sum", Empty().Sum(), []interface{}{0}},
+		{"empty count", Empty().Count(), []interface{}{0}},
+	}
+	for _, test := range tests {
+		values, err := collect(test.obs)
+		if err != nil || !reflect.DeepEqual(values, test.expected) {
+			t.Errorf("%s: got %v, %v", test.name, values, err)
+		}
+	}
+}
+
+func TestAggregateErrors(t *testing.T) {
+	boom := errors.New("boom")
+	failing := Create(func(sub Subscriber) {
+		sub.Notify(Next(1))
+		sub.Notify(Error(boom))
+		sub.Notify(Complete())
+	})
+	for _, obs := range []Observable{failing.Count(), failing.Sum(), failing.Reduce(0, func(acc, v interface{}) interface{} {
+		return v
+	})} {
+		values, err := collect(obs)
+		if err != boom || len(values) != 0 {
+			t.Errorf("expected only the error, got %v, %v", values, err)
+		}
+	}
+
+	values, err := collect(Just(1, "two").Sum())
+	if !errors.Is(err, ErrNotNumeric) || len(values) != 0 {
+		t.Errorf("expected ErrNotNumeric, got %v, %v", values, err)
+	}
+}
@@ -0,0 +1,135 @@
+package urx
+
+import (
+	"errors"
+	"sync"
+)
+
+// what a published observable does when a subscriber's queue is full
+type BackpressureStrategy int
+
+const (
+	// wait for the subscriber to catch up, which holds back every other subscriber
+	BackpressureBlock BackpressureStrategy = iota
+	// drop the notification which did not fit
+	BackpressureDropNewest
+	// drop the oldest queued notification to make room
+	BackpressureDropOldest
+	// keep only the latest notification (ignoring the size)
+	BackpressureKeepLatest
+	// disconnect the subscriber with ErrSlowSubscriber
+	BackpressureError
+)
+
+// sent to subscribers which are disconnected by BackpressureError
+var ErrSlowSubscriber = errors.New("urx: subscriber could not keep up with a published observable")
+
+// how many notifications may be queued for a subscriber of a published observable (at least 1),
+// and what to do when there are more. OnError and OnComplete are always queued
+type Backpressure struct {
+	Strategy BackpressureStrategy
+	Size     int
+}
+
+func (bp Backpressure) size() int {
+	if bp.Strategy == BackpressureKeepLatest || bp.Size < 1 {
+		return 1
+	}
+	return bp.Size
+}
+
+type targetQueue struct {
+	Backpressure
+	target *simpleSubscriber
+	mutex  sync.Mutex
+	cond   *sync.Cond
+	items  []Notification
+	//no more notifications will be queued
+	closed bool
+}
+
+func newTargetQueue(target *simpleSubscriber, bp Backpressure) *targetQueue {
+	q := &targetQueue{Backpressure: bp, target: target}
+	q.cond = sync.NewCond(&q.mutex)
+	return q
+}
+
+func (q *targetQueue) push(n Notification) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	if q.closed {
+		return
+	}
+	if n.Type == OnNext && len(q.items) >= q.size() {
+		switch q.Strategy {
+		case BackpressureBlock:
+			for len(q.items) >= q.size() && !q.closed {
+				q.cond.Wait()
+			}
+			if q.closed {
+				return
+			}
+		case BackpressureDropNewest:
+			return
+		case BackpressureDropOldest, BackpressureKeepLatest:
+			q.items = q.items[1:]
+		case BackpressureError:
+			q.items = []Notification{Error(ErrSlowSubscriber), Complete()}
+			q.closed = true
+			q.cond.Broadcast()
+			return
+		}
+	}
+	q.items = append(q.items, n)
+	if n.Type == OnComplete {
+		q.closed = true
+	}
+	q.cond.Broadcast()
+}
+
+// discards anything queued, called once the target is done
+func (q *targetQueue) close() {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	q.closed = true
+	q.items = nil
+	q.cond.Broadcast()
+}
+
+// delivers the initial notifications and then everything queued to the target, until the queue is closed
+func (q *targetQueue) deliver(initial []Notification) {
+	q.target.notifyAll(initial)
+	for {
+		q.mutex.Lock()
+		for len(q.items) == 0 && !q.closed {
+			q.cond.Wait()
+		}
+		if len(q.items) == 0 {
+			q.mutex.Unlock()
+			return
+		}
+		n := q.items[0]
+		q.items = q.items[1:]
+		q.cond.Broadcast()
+		q.mutex.Unlock()
+		q.target.Notify(n)
+	}
+}
+
+type backpressureObservable struct {
+	obs *publishedObservable
+	bp  Backpressure
+}
+
+// subscriptions to the returned observable use the backpressure policy
+func (p pObservable) WithBackpressure(bp Backpressure) Observable {
+	return bObservable{&backpressureObservable{p.published(), bp}}
+}
+
+func (b *backpressureObservable) privSubscribe() privSubscription {
+	return b.obs.subscribeTarget(b.bp)
+}
+
+func (b *backpressureObservable) Lift(newOp OperatorFactory) privObservable {
+	return &liftedObservable{source: b, newOp: newOp}
+}
@@ -0,0 +1,49 @@
+package urx
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBackpressure(t *testing.T) {
+	cases := []struct {
+		bp       Backpressure
+		expected []interface{}
+		err      error
+	}{
+		{Backpressure{BackpressureDropNewest, 3}, []interface{}{0, 1, 2}, nil},
+		{Backpressure{BackpressureDropOldest, 3}, []interface{}{97, 98, 99}, nil},
+		{Backpressure{BackpressureKeepLatest, 3}, []interface{}{99}, nil},
+		{Backpressure{BackpressureError, 3}, nil, ErrSlowSubscriber},
+	}
+	for _, c := range cases {
+		conn := Range(0, 100).Connectable()
+		fast := conn.Subscribe()
+		//nothing reads from the slow subscription until the source has finished, so its queue
+		//overflows while the fast subscriber carries on
+		slow := conn.WithBackpressure(c.bp).Subscribe()
+		conn.Connect()
+
+		got := 0
+		for range fast.Values() {
+			got++
+		}
+		if got != 100 {
+			t.Errorf("%v: the fast subscriber got %d values instead of 100", c.bp, got)
+		}
+
+		var values []interface{}
+		var err error
+		for n := range slow.Events() {
+			switch n.Type {
+			case OnNext:
+				values = append(values, n.Body)
+			case OnError:
+				err = n.Error()
+			}
+		}
+		if !reflect.DeepEqual(values, c.expected) || err != c.err {
+			t.Errorf("%v: expected %v and %v but got %v and %v", c.bp, c.expected, c.err, values, err)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package urx
+
+import "time"
+
+// emits the values in []interface{} batches of size, starting a new batch every skip values (or every
+// size values if skip is 0 or less). Any partial batches are emitted when the source completes.
+// Panics if size is not positive
+func (o bObservable) BufferCount(size, skip int) Observable {
+	if size <= 0 {
+		panic("urx: BufferCount needs a positive size")
+	}
+	if skip <= 0 {
+		skip = size
+	}
+	return o.LiftFunc(func() Operator {
+		var open [][]interface{}
+		seen := 0
+		return FunctionOperator(func(sub Subscriber, n Notification) {
+			switch n.Type {
+			case OnNext:
+				if seen%skip == 0 {
+					open = append(open, make([]interface{}, 0, size))
+				}
+				seen++
+				for i := range open {
+					open[i] = append(open[i], n.Body)
+				}
+				if len(open) > 0 && len(open[0]) >= size {
+					sub.Notify(Next(open[0]))
+					open = open[1:]
+				}
+				return
+			case OnComplete:
+				for _, batch := range open {
+					if len(batch) > 0 {
+						sub.Notify(Next(batch))
+					}
+				}
+			}
+			sub.Notify(n)
+		})
+	})
+}
+
+// emits the values which arrived in each period of d as a []interface{} batch, skipping empty batches.
+// The partial batch is emitted when the source completes. Panics if d is not positive
+func (o bObservable) BufferTime(d time.Duration, clock ...Clock) Observable {
+	return o.BufferTimeOrCount(d, 0, clock...)
+}
+
+// like BufferTime, but a batch is also emitted as soon as it has max values (unless max is 0 or less),
+// which starts the next period. Panics if d is not positive
+func (o bObservable) BufferTimeOrCount(d time.Duration, max int, clock ...Clock) Observable {
+	if d <= 0 {
+		panic("urx: BufferTimeOrCount needs a positive period")
+	}
+	c := clockOf(clock)
+	return Create(func(sub Subscriber) {
+		inner := subscribeFor(o, sub)
+		timer := &restartableTimer{clock: c}
+		defer timer.stop()
+		timer.start(d)
+		var batch batch
+		for {
+			select {
+			case n, ok := <-inner.Events():
+				switch {
+				case !ok:
+					return
+				case n.Type == OnNext:
+					batch.add(n.Body)
+					if max > 0 && len(batch) >= max {
+						batch.flush(sub)
+						timer.start(d)
+					}
+				case n.Type == OnComplete:
+					batch.flush(sub)
+					sub.Notify(n)
+					return
+				case n.Type == OnError:
+					sub.Notify(n)
+					return
+				}
+			case <-timer.C():
+				batch.flush(sub)
+				timer.start(d)
+			}
+		}
+	})
+}
+
+// emits the values which arrived before each value from closing as a []interface{} batch, skipping
+// empty batches. The partial batch is emitted when the source completes
+func (o bObservable) BufferWhen(closing Observable) Observable {
+	return Create(func(sub Subscriber) {
+		inner := subscribeFor(o, sub)
+		closings := subscribeFor(closing, sub).Events()
+		var batch batch
+		for {
+			select {
+			case n, ok := <-inner.Events():
+				switch {
+				case !ok:
+					return
+				case n.Type == OnNext:
+					batch.add(n.Body)
+				case n.Type == OnComplete:
+					batch.flush(sub)
+					sub.Notify(n)
+					return
+				case n.Type == OnError:
+					sub.Notify(n)
+					return
+				}
+			case n, ok := <-closings:
+				switch {
+				case !ok || n.Type == OnComplete:
+					closings = nil
+				case n.Type == OnNext:
+					batch.flush(sub)
+				case n.Type == OnError:
+					sub.Notify(n)
+					return
+				}
+			}
+		}
+	})
+}
+
+type batch []interface{}
+
+func (b *batch) add(v interface{}) {
+	*b = append(*b, v)
+}
+
+// emits the batch unless it is empty, starting a new one
+func (b *batch) flush(sub Subscriber) {
+	if len(*b) > 0 {
+		sub.Notify(Next([]interface{}(*b)))
+		*b = nil
+	}
+}
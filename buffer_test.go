@@ -0,0 +1,32 @@
+package urx
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBufferCountSize(t *testing.T) {
+	for _, size := range []int{0, -1} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("BufferCount accepted a size of %d", size)
+				}
+			}()
+			Just(1).BufferCount(size, 0)
+		}()
+	}
+}
+
+func TestBufferTimePeriod(t *testing.T) {
+	for _, d := range []time.Duration{0, -time.Second} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("BufferTime accepted a period of %v", d)
+				}
+			}()
+			Just(1).BufferTime(d)
+		}()
+	}
+}
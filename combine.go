@@ -0,0 +1,175 @@
+package urx
+
+import "context"
+
+type sourceEvent struct {
+	index int
+	n     Notification
+}
+
+// subscribes to every observable, forwarding their notifications (other than OnStart) tagged with
+// the index of their source until the context is done. stop unsubscribes from all of them
+func mergeIndexed(ctx context.Context, obs []Observable) (events <-chan sourceEvent, stop func()) {
+	out := make(chan sourceEvent)
+	subs := make([]Subscription, len(obs))
+	for i := range obs {
+		subs[i] = obs[i].Subscribe()
+		go func(i int, inner Subscription) {
+			for n := range inner.Events() {
+				if n.Type == OnStart {
+					continue
+				}
+				select {
+				case out <- sourceEvent{i, n}:
+				case <-ctx.Done():
+					return
+				}
+				if n.Type == OnComplete {
+					return
+				}
+			}
+		}(i, subs[i])
+	}
+	return out, func() {
+		for _, sub := range subs {
+			sub.Unsubscribe()
+		}
+	}
+}
+
+// emits a []interface{} of the nth value from each observable, completing once any observable
+// completes and none of its values are left waiting
+func Zip(obs ...Observable) Observable {
+	if len(obs) == 0 {
+		return Empty()
+	}
+	return CreateContext(func(ctx context.Context, sub Subscriber) {
+		events, stop := mergeIndexed(ctx, obs)
+		defer stop()
+		buffers := make([][]interface{}, len(obs))
+		completed := make([]bool, len(obs))
+		//whether any observable has completed with nothing left waiting
+		exhausted := func() bool {
+			for i := range obs {
+				if completed[i] && len(buffers[i]) == 0 {
+					return true
+				}
+			}
+			return false
+		}
+		for {
+			select {
+			case e := <-events:
+				switch e.n.Type {
+				case OnNext:
+					buffers[e.index] = append(buffers[e.index], e.n.Body)
+					ready := true
+					for i := range buffers {
+						ready = ready && len(buffers[i]) > 0
+					}
+					if ready {
+						values := make([]interface{}, len(obs))
+						for i := range buffers {
+							values[i] = buffers[i][0]
+							buffers[i] = buffers[i][1:]
+						}
+						sub.Notify(Next(values))
+					}
+				case OnError:
+					sub.Notify(e.n)
+					return
+				case OnComplete:
+					completed[e.index] = true
+				}
+				if exhausted() {
+					sub.Notify(Complete())
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+}
+
+// once every observable has emitted, emits the combination of the latest value from each whenever any
+// of them emits. If combiner is nil the values are emitted as a []interface{}. Completes once they all
+// have, or as soon as one completes without emitting anything
+func CombineLatest(combiner func([]interface{}) interface{}, obs ...Observable) Observable {
+	if len(obs) == 0 {
+		return Empty()
+	}
+	if combiner == nil {
+		combiner = func(values []interface{}) interface{} {
+			return values
+		}
+	}
+	return CreateContext(func(ctx context.Context, sub Subscriber) {
+		events, stop := mergeIndexed(ctx, obs)
+		defer stop()
+		latest := make([]interface{}, len(obs))
+		has := make([]bool, len(obs))
+		emitted, completed := 0, 0
+		for {
+			select {
+			case e := <-events:
+				switch e.n.Type {
+				case OnNext:
+					if !has[e.index] {
+						has[e.index] = true
+						emitted++
+					}
+					latest[e.index] = e.n.Body
+					if emitted == len(obs) {
+						sub.Notify(Next(combiner(append([]interface{}(nil), latest...))))
+					}
+				case OnError:
+					sub.Notify(e.n)
+					return
+				case OnComplete:
+					completed++
+					if completed == len(obs) || !has[e.index] {
+						sub.Notify(Complete())
+						return
+					}
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+}
+
+// emits a []interface{} of each value and the latest value from other, once other has emitted.
+// Completes when the source does
+func (o bObservable) WithLatestFrom(other Observable) Observable {
+	return CreateContext(func(ctx context.Context, sub Subscriber) {
+		events, stop := mergeIndexed(ctx, []Observable{o, other})
+		defer stop()
+		var latest interface{}
+		has := false
+		for {
+			select {
+			case e := <-events:
+				switch {
+				case e.n.Type == OnError:
+					sub.Notify(e.n)
+					return
+				case e.index == 1:
+					if e.n.Type == OnNext {
+						latest, has = e.n.Body, true
+					}
+				case e.n.Type == OnNext:
+					if has {
+						sub.Notify(Next([]interface{}{e.n.Body, latest}))
+					}
+				case e.n.Type == OnComplete:
+					sub.Notify(e.n)
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+}
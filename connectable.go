@@ -0,0 +1,118 @@
+package urx
+
+import "sync"
+
+// a connection between a ConnectableObservable and its source
+type Connection interface {
+	Unsubscribe()
+	RootSubscriber
+}
+
+// a published observable which only subscribes to its source when told to
+type ConnectableObservable interface {
+	PublishedObservable
+	// subscribes to the source, unless already subscribed. Unsubscribing the connection disconnects
+	Connect() Connection
+	// connects when the first subscriber arrives and disconnects when the last one leaves,
+	// reconnecting for the next subscriber (even if the source has completed)
+	RefCount() Observable
+	// connects once n subscribers have arrived, or straight away if n <= 0
+	AutoConnect(n int) Observable
+}
+
+func finishedSubscription() privSubscription {
+	sub := initSimpleSubscriber()
+	sub.handleComplete()
+	return sub
+}
+
+// creates a connectable observable, see Publish for the scheduler
+func (o bObservable) Connectable(scheduler ...Scheduler) ConnectableObservable {
+	o.privObservable = published(o.privObservable, schedulerOf(scheduler))
+	return pObservable{o}
+}
+
+// shares a single subscription to the observable between all of its subscribers while there are any
+func (o bObservable) Share() Observable {
+	return o.Connectable().RefCount()
+}
+
+func (p pObservable) published() *publishedObservable {
+	return p.privObservable.(*publishedObservable)
+}
+
+func (p pObservable) Connect() Connection {
+	return p.published().connect()
+}
+
+func (p pObservable) RefCount() Observable {
+	return bObservable{&refCountObservable{obs: p.published()}}
+}
+
+func (p pObservable) AutoConnect(n int) Observable {
+	if n <= 0 {
+		p.Connect()
+	}
+	return bObservable{&autoConnectObservable{obs: p.published(), n: n}}
+}
+
+type refCountObservable struct {
+	obs   *publishedObservable
+	mutex sync.Mutex
+	count int
+	conn  Connection
+}
+
+func (r *refCountObservable) privSubscribe() privSubscription {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.count == 0 {
+		r.obs.reset()
+	}
+	target := r.obs.privSubscribe()
+	r.count++
+	if r.count == 1 {
+		r.conn = r.obs.connect()
+	}
+	//hooks are called with the published observable's lock held, which privSubscribe takes after ours
+	target.Add(func() {
+		go r.release()
+	})
+	return target
+}
+
+func (r *refCountObservable) release() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.count--
+	if r.count == 0 {
+		r.conn.Unsubscribe()
+		r.conn = nil
+	}
+}
+
+func (r *refCountObservable) Lift(newOp OperatorFactory) privObservable {
+	return &liftedObservable{source: r, newOp: newOp}
+}
+
+type autoConnectObservable struct {
+	obs   *publishedObservable
+	n     int
+	mutex sync.Mutex
+	count int
+}
+
+func (a *autoConnectObservable) privSubscribe() privSubscription {
+	target := a.obs.privSubscribe()
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.count++
+	if a.count == a.n {
+		a.obs.connect()
+	}
+	return target
+}
+
+func (a *autoConnectObservable) Lift(newOp OperatorFactory) privObservable {
+	return &liftedObservable{source: a, newOp: newOp}
+}
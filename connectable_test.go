@@ -0,0 +1,87 @@
+package urx
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// counts how many times the returned observable has been subscribed to
+func countSubscriptions(obs Observable) (Observable, *int32) {
+	var count int32
+	return Defer(func() Observable {
+		atomic.AddInt32(&count, 1)
+		return obs
+	}), &count
+}
+
+func TestConnect(t *testing.T) {
+	obs, count := countSubscriptions(Range(0, 3))
+	conn := obs.Connectable()
+	sub := conn.Subscribe()
+	<-time.After(time.Millisecond * 10)
+	if atomic.LoadInt32(count) != 0 {
+		t.Fatal("the source was subscribed to before Connect")
+	}
+
+	connection := conn.Connect()
+	conn.Connect()
+	got := 0
+	for range sub.Values() {
+		got++
+	}
+	if got != 3 || atomic.LoadInt32(count) != 1 {
+		t.Errorf("expected 3 values from one connection but got %d from %d", got, atomic.LoadInt32(count))
+	}
+	<-time.After(time.Millisecond * 10)
+	if connection.IsSubscribed() {
+		t.Error("the connection is still subscribed after the source completed")
+	}
+}
+
+func TestRefCount(t *testing.T) {
+	obs, count := countSubscriptions(Interval(time.Millisecond * 5))
+	shared := obs.Share()
+
+	one, two := shared.Subscribe(), shared.Subscribe()
+	<-one.Values()
+	<-two.Values()
+	one.Unsubscribe()
+	two.Unsubscribe()
+	<-time.After(time.Millisecond * 10)
+
+	three := shared.Subscribe()
+	<-three.Values()
+	three.Unsubscribe()
+	if n := atomic.LoadInt32(count); n != 2 {
+		t.Errorf("expected the source to be subscribed to twice but it was %d times", n)
+	}
+}
+
+func TestAutoConnect(t *testing.T) {
+	obs, count := countSubscriptions(Range(0, 3))
+	auto := obs.Connectable().AutoConnect(2)
+
+	first := auto.Subscribe()
+	<-time.After(time.Millisecond * 10)
+	if atomic.LoadInt32(count) != 0 {
+		t.Fatal("connected before the second subscriber")
+	}
+	second := auto.Subscribe()
+	var wg sync.WaitGroup
+	for _, sub := range []Subscription{first, second} {
+		wg.Add(1)
+		go func(values <-chan interface{}) {
+			defer wg.Done()
+			got := 0
+			for range values {
+				got++
+			}
+			if got != 3 {
+				t.Errorf("expected 3 values but got %d", got)
+			}
+		}(sub.Values())
+	}
+	wg.Wait()
+}
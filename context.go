@@ -0,0 +1,33 @@
+package urx
+
+import "context"
+
+// creates an observable from a function which is given a context that is cancelled
+// as soon as the subscriber completes or is unsubscribed
+func CreateContext(onSub func(context.Context, Subscriber), scheduler ...Scheduler) Observable {
+	return Create(func(sub Subscriber) {
+		ctx, cancel := context.WithCancel(context.Background())
+		sub.Add(CompleteHook(cancel))
+		if !sub.IsSubscribed() {
+			cancel()
+		}
+		onSub(ctx, sub)
+	}, scheduler...)
+}
+
+// subscribes to the observable, unsubscribing when the context is done
+func (o bObservable) SubscribeContext(ctx context.Context) Subscription {
+	sub := o.Subscribe()
+	done := make(chan interface{})
+	sub.Add(func() {
+		close(done)
+	})
+	go func() {
+		select {
+		case <-ctx.Done():
+			sub.Unsubscribe()
+		case <-done:
+		}
+	}()
+	return sub
+}
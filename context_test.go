@@ -0,0 +1,48 @@
+package urx
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestSubscribeContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sub := createChanObs(-1, time.Millisecond*5).SubscribeContext(ctx)
+
+	got := 0
+	for range sub.Values() {
+		got++
+		if got == 3 {
+			cancel()
+		}
+	}
+	if sub.IsSubscribed() {
+		t.Error("subscription still active after the context was cancelled")
+	}
+}
+
+func TestCreateContext(t *testing.T) {
+	stopped := make(chan interface{})
+	obs := CreateContext(func(ctx context.Context, sub Subscriber) {
+		defer close(stopped)
+		for i := 0; ; i++ {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Millisecond * 5):
+				sub.Notify(Next(i))
+			}
+		}
+	})
+
+	sub := obs.Subscribe()
+	<-sub.Values()
+	sub.Unsubscribe()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Error("producer was not cancelled by unsubscription")
+	}
+}
@@ -0,0 +1,145 @@
+package urx
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// returned by Distinct and DistinctUntilKeyChanged when a key cannot be compared with ==
+var ErrNotComparable = errors.New("urx: key is not comparable")
+
+type DistinctOptions struct {
+	// the most keys to remember, forgetting the oldest first, or 0 for no limit
+	Size int
+	// how long a key is remembered after its value is emitted, or 0 for forever
+	TTL time.Duration
+	// times the TTL, SystemClock by default
+	Clock Clock
+}
+
+// ignores values equal to the value before them. Values are compared with reflect.DeepEqual if equal is nil
+func (o bObservable) DistinctUntilChanged(equal func(a, b interface{}) bool) Observable {
+	if equal == nil {
+		equal = reflect.DeepEqual
+	}
+	return o.LiftFunc(func() Operator {
+		var last interface{}
+		started := false
+		return FunctionOperator(func(sub Subscriber, n Notification) {
+			if n.Type == OnNext {
+				if started && equal(last, n.Body) {
+					return
+				}
+				last = n.Body
+				started = true
+			}
+			sub.Notify(n)
+		})
+	})
+}
+
+// ignores values whose key is the same as the key of the value before them. Keys must be
+// comparable, a key which is not ends the stream with ErrNotComparable
+func (o bObservable) DistinctUntilKeyChanged(key func(interface{}) interface{}) Observable {
+	return o.LiftFunc(func() Operator {
+		var last interface{}
+		started := false
+		return FunctionOperator(func(sub Subscriber, n Notification) {
+			if n.Type == OnNext {
+				k := key(n.Body)
+				if err := checkComparable(k); err != nil {
+					sub.Notify(Error(err))
+					sub.Notify(Complete())
+					return
+				}
+				if started && last == k {
+					return
+				}
+				last = k
+				started = true
+			}
+			sub.Notify(n)
+		})
+	})
+}
+
+func checkComparable(k interface{}) error {
+	if k != nil && !reflect.ValueOf(k).Comparable() {
+		return fmt.Errorf("%w: %T", ErrNotComparable, k)
+	}
+	return nil
+}
+
+// ignores values whose key has already been seen, where the key is the value itself if key is nil.
+// Keys must be comparable, a key which is not ends the stream with ErrNotComparable.
+// By default every key is remembered for as long as the subscription lasts
+func (o bObservable) Distinct(key func(interface{}) interface{}, opts ...DistinctOptions) Observable {
+	var options DistinctOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+	if options.Clock == nil {
+		options.Clock = SystemClock
+	}
+	return o.LiftFunc(func() Operator {
+		seen := &seenKeys{DistinctOptions: options, keys: make(map[interface{}]bool)}
+		return FunctionOperator(func(sub Subscriber, n Notification) {
+			if n.Type == OnNext {
+				k := n.Body
+				if key != nil {
+					k = key(k)
+				}
+				if err := checkComparable(k); err != nil {
+					sub.Notify(Error(err))
+					sub.Notify(Complete())
+					return
+				}
+				if !seen.add(k) {
+					return
+				}
+			}
+			sub.Notify(n)
+		})
+	})
+}
+
+type seenKey struct {
+	key interface{}
+	at  time.Time
+}
+
+type seenKeys struct {
+	DistinctOptions
+	keys map[interface{}]bool
+	//oldest first, each key appears once
+	order []seenKey
+}
+
+// remembers the key, returning false if it was already remembered
+func (s *seenKeys) add(key interface{}) bool {
+	now := s.Clock.Now()
+	if s.TTL > 0 {
+		cutoff := now.Add(-s.TTL)
+		for len(s.order) > 0 && !s.order[0].at.After(cutoff) {
+			s.forgetOldest()
+		}
+	}
+	if s.keys[key] {
+		return false
+	}
+	s.keys[key] = true
+	if s.Size > 0 || s.TTL > 0 {
+		s.order = append(s.order, seenKey{key, now})
+	}
+	if s.Size > 0 && len(s.order) > s.Size {
+		s.forgetOldest()
+	}
+	return true
+}
+
+func (s *seenKeys) forgetOldest() {
+	delete(s.keys, s.order[0].key)
+	s.order = s.order[1:]
+}
@@ -0,0 +1,24 @@
+package urx
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDistinctNotComparable(t *testing.T) {
+	source := Just([]byte("a"), []byte("b"))
+	for name, obs := range map[string]Observable{
+		"Distinct": source.Distinct(nil),
+		"DistinctUntilKeyChanged": source.DistinctUntilKeyChanged(func(in interface{}) interface{} {
+			return in
+		}),
+		"DistinctUntilKeyChanged with an interface key": source.DistinctUntilKeyChanged(func(in interface{}) interface{} {
+			return struct{ key interface{} }{in}
+		}),
+	} {
+		values, err := collect(obs)
+		if !errors.Is(err, ErrNotComparable) || len(values) != 0 {
+			t.Errorf("%s: expected ErrNotComparable, got %v, %v", name, values, err)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package urx
+
+import "context"
+
+// the inner observables of a flattening operator, owned by the operator's goroutine
+type flattener struct {
+	project func(interface{}) Observable
+	//the most inners subscribed at once, or 0 for no limit
+	limit int
+	//outer values waiting for an inner to complete
+	queue  []interface{}
+	active map[int]Subscription
+	nextID int
+	events chan innerEvent
+	done   chan interface{}
+}
+
+type innerEvent struct {
+	id int
+	n  Notification
+}
+
+// subscribes to the inner observable for the value, forwarding its notifications to events
+func (f *flattener) subscribe(v interface{}) {
+	id := f.nextID
+	f.nextID++
+	inner := f.project(v).Subscribe()
+	f.active[id] = inner
+	go func() {
+		for n := range inner.Events() {
+			if n.Type == OnStart {
+				continue
+			}
+			select {
+			case f.events <- innerEvent{id, n}:
+			case <-f.done:
+				return
+			}
+			if n.Type == OnComplete {
+				return
+			}
+		}
+	}()
+}
+
+// subscribes to the inner for the value, or queues it if the limit has been reached
+func (f *flattener) merge(v interface{}) {
+	if f.limit > 0 && len(f.active) >= f.limit {
+		f.queue = append(f.queue, v)
+		return
+	}
+	f.subscribe(v)
+}
+
+// forgets a completed inner, subscribing to the next queued one
+func (f *flattener) completed(id int) {
+	delete(f.active, id)
+	if len(f.queue) > 0 {
+		v := f.queue[0]
+		f.queue = f.queue[1:]
+		f.subscribe(v)
+	}
+}
+
+// unsubscribes from the active inners and subscribes to the inner for the value
+func (f *flattener) switchTo(v interface{}) {
+	f.cancel()
+	f.subscribe(v)
+}
+
+// subscribes to the inner for the value unless one is already active
+func (f *flattener) exhaust(v interface{}) {
+	if len(f.active) == 0 {
+		f.subscribe(v)
+	}
+}
+
+// unsubscribes from every active inner. Anything they have already sent is ignored
+func (f *flattener) cancel() {
+	for id, inner := range f.active {
+		inner.Unsubscribe()
+		delete(f.active, id)
+	}
+}
+
+func (f *flattener) busy() bool {
+	return len(f.active) > 0 || len(f.queue) > 0
+}
+
+func (f *flattener) stop() {
+	close(f.done)
+	f.cancel()
+}
+
+// runs a flattening operator, where onNext decides what to do with the inner for each outer value.
+// The first error from the source or an inner is passed on, and the output completes once the source
+// and all of the inners have
+func (o bObservable) flatten(project func(interface{}) Observable, limit int, onNext func(*flattener, interface{})) Observable {
+	return CreateContext(func(ctx context.Context, sub Subscriber) {
+		outer := subscribeFor(o, sub)
+		outerEvents := outer.Events()
+		f := &flattener{
+			project: project,
+			limit:   limit,
+			active:  make(map[int]Subscription),
+			events:  make(chan innerEvent),
+			done:    make(chan interface{}),
+		}
+		defer f.stop()
+		for outerEvents != nil || f.busy() {
+			select {
+			case n, ok := <-outerEvents:
+				switch {
+				case !ok:
+					return
+				case n.Type == OnNext:
+					onNext(f, n.Body)
+				case n.Type == OnError:
+					sub.Notify(n)
+					return
+				case n.Type == OnComplete:
+					outerEvents = nil
+				}
+			case e := <-f.events:
+				if _, ok := f.active[e.id]; !ok {
+					continue
+				}
+				switch e.n.Type {
+				case OnNext:
+					sub.Notify(e.n)
+				case OnError:
+					sub.Notify(e.n)
+					return
+				case OnComplete:
+					f.completed(e.id)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+		sub.Notify(Complete())
+	})
+}
+
+// maps each value to an inner observable and merges their values, subscribing to at most
+// maxConcurrent inners at once (queuing the rest in order) if it is given
+func (o bObservable) FlatMap(project func(interface{}) Observable, maxConcurrent ...int) Observable {
+	limit := 0
+	if len(maxConcurrent) > 0 {
+		limit = maxConcurrent[0]
+	}
+	return o.flatten(project, limit, (*flattener).merge)
+}
+
+// maps each value to an inner observable and emits their values one inner at a time, in order
+func (o bObservable) ConcatMap(project func(interface{}) Observable) Observable {
+	return o.flatten(project, 1, (*flattener).merge)
+}
+
+// maps each value to an inner observable and emits the values of the latest one, unsubscribing
+// from the previous inner as soon as a new value arrives
+func (o bObservable) SwitchMap(project func(interface{}) Observable) Observable {
+	return o.flatten(project, 0, (*flattener).switchTo)
+}
+
+// maps each value to an inner observable and emits its values, ignoring values which arrive
+// while an inner is still active
+func (o bObservable) ExhaustMap(project func(interface{}) Observable) Observable {
+	return o.flatten(project, 0, (*flattener).exhaust)
+}
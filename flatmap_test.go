@@ -0,0 +1,39 @@
+package urx
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFlatMapUnsubscribesInners(t *testing.T) {
+	var running int32
+	inner := CreateContext(func(ctx context.Context, sub Subscriber) {
+		atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for i := 0; ; i++ {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Millisecond):
+				sub.Notify(Next(i))
+			}
+		}
+	})
+	obs := Range(0, 3).FlatMap(func(interface{}) Observable {
+		return inner
+	}).Take(10)
+
+	values, err := collect(obs)
+	if err != nil || len(values) != 10 {
+		t.Errorf("got %v, %v", values, err)
+	}
+	deadline := time.Now().Add(time.Second)
+	for atomic.LoadInt32(&running) > 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if n := atomic.LoadInt32(&running); n > 0 {
+		t.Errorf("%d inners still running", n)
+	}
+}
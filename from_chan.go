@@ -1,16 +1,26 @@
 package urx
 
-import "reflect"
+import (
+	"context"
+	"reflect"
+)
 
-func FromChan(source interface{}) Observable {
+func FromChan(source interface{}, scheduler ...Scheduler) Observable {
 	val := reflect.ValueOf(source)
 	if val.Kind() != reflect.Chan {
 		panic("a channel was not passed to urx.FromChan")
 	}
 
-	sub := func(sub Subscriber) {
+	return CreateContext(func(ctx context.Context, sub Subscriber) {
+		cases := []reflect.SelectCase{
+			{Dir: reflect.SelectRecv, Chan: val},
+			{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())},
+		}
 		for {
-			next, ok := val.Recv()
+			chosen, next, ok := reflect.Select(cases)
+			if chosen == 1 {
+				return
+			}
 			if !ok {
 				sub.Notify(Notification{Body: nil, Type: OnComplete})
 				return
@@ -18,8 +28,5 @@ func FromChan(source interface{}) Observable {
 				sub.Notify(Notification{Body: next.Interface(), Type: OnNext})
 			}
 		}
-	}
-
-	out := simpleObservable{&sub}
-	return bObservable{out}
+	}, scheduler...)
 }
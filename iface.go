@@ -1,24 +1,29 @@
 package urx
 
-// creates an observable from a function
-func Create(onSub func(Subscriber)) Observable {
-	return bObservable{simpleObservable{&onSub}}
+import "context"
+
+// creates an observable from a function, which is called on the scheduler (a new goroutine by default)
+func Create(onSub func(Subscriber), scheduler ...Scheduler) Observable {
+	return bObservable{simpleObservable{&onSub, schedulerOf(scheduler)}}
 }
 
 // creates a published observable from an observable
-func published(source privObservable) *publishedObservable {
-	return &publishedObservable{source: source, targets: make(map[*simpleSubscriber]*simpleSubscriber)}
+func published(source privObservable, scheduler Scheduler) *publishedObservable {
+	return &publishedObservable{source: source, scheduler: scheduler, targets: make(map[*simpleSubscriber]*targetQueue)}
 }
 
 type Operator interface {
 	Notify(Subscriber, Notification)
 }
 
+// creates the operator for a single subscription, so any state it keeps is not shared between subscriptions
+type OperatorFactory func() Operator
+
 // The generic observable interface is what fundamentally defines an observable
 // an observable can be subscribed to, and can be used to create derived observables
 type privObservable interface {
 	privSubscribe() privSubscription
-	Lift(Operator) privObservable
+	Lift(OperatorFactory) privObservable
 }
 
 type privSubscription interface {
@@ -42,7 +47,9 @@ type Subject interface {
 	Next(interface{})
 	Error(error)
 	Complete()
-	Post(Notification)
+	Post(Notification) error
+	TryPost(Notification) bool
+	PostContext(context.Context, Notification) error
 	Subscribe() Subscription
 	AsObservable() Observable
 }
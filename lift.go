@@ -4,7 +4,7 @@ import "sync"
 
 type liftedObservable struct {
 	source privObservable
-	op     Operator
+	newOp  OperatorFactory
 }
 
 type liftedSubscriber struct {
@@ -15,25 +15,31 @@ type liftedSubscriber struct {
 	cMutex   sync.RWMutex
 	uMutex   sync.Mutex
 	unsubbed bool
+	closed   bool
 	hooks
 }
 
+// the operator runs on a new goroutine for every subscription, which no Scheduler controls
 func (lifted *liftedObservable) privSubscribe() (sub privSubscription) {
-	out := &liftedSubscriber{source: lifted.source.privSubscribe(), op: lifted.op, events: make(chan Notification), unsub: make(chan interface{})}
+	out := &liftedSubscriber{source: lifted.source.privSubscribe(), op: lifted.newOp(), events: make(chan Notification), unsub: make(chan interface{})}
 	go out.pump()
 	sub = out
 	return
 }
 
-func (lifted *liftedObservable) Lift(op Operator) (obs privObservable) {
-	obs = &liftedObservable{source: lifted, op: op}
+func (lifted *liftedObservable) Lift(newOp OperatorFactory) (obs privObservable) {
+	obs = &liftedObservable{source: lifted, newOp: newOp}
 	return
 }
 
+// the subscriber finishes once the source does, even if the operator never passes on OnComplete
 func (sub *liftedSubscriber) pump() {
-	defer close(sub.events)
+	defer sub.Unsubscribe()
 	for ev := range sub.source.Events() {
 		sub.op.Notify(sub, ev)
+		if ev.Type == OnComplete {
+			return
+		}
 	}
 }
 
@@ -53,15 +59,23 @@ func (sub *liftedSubscriber) Unsubscribe() {
 	sub.uMutex.Unlock()
 	sub.cMutex.Lock()
 	defer sub.cMutex.Unlock()
+	close(sub.events)
+	sub.closed = true
 	sub.callHooks()
 }
 
 func (sub *liftedSubscriber) IsSubscribed() bool {
-	return !sub.unsubbed && sub.source.IsSubscribed() && !sub.finished
+	sub.uMutex.Lock()
+	defer sub.uMutex.Unlock()
+	return !sub.unsubbed
 }
 
 func (sub *liftedSubscriber) Notify(not Notification) {
 	sub.cMutex.RLock()
+	if sub.closed {
+		sub.cMutex.RUnlock()
+		return
+	}
 	select {
 	case sub.events <- not:
 		sub.cMutex.RUnlock()
@@ -0,0 +1,67 @@
+package urx
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLiftFunc(t *testing.T) {
+	created := 0
+	obs := Range(0, 3).LiftFunc(func() Operator {
+		created++
+		seen := 0
+		return FunctionOperator(func(sub Subscriber, n Notification) {
+			if n.Type == OnNext {
+				seen++
+				n.Body = seen
+			}
+			sub.Notify(n)
+		})
+	})
+	for i := 0; i < 2; i++ {
+		values, _ := collect(obs)
+		if !reflect.DeepEqual(values, []interface{}{1, 2, 3}) {
+			t.Errorf("subscription %d got %v", i, values)
+		}
+	}
+	if created != 2 {
+		t.Errorf("expected an operator per subscription, got %d", created)
+	}
+}
+
+func TestBufferedSubscribedTwice(t *testing.T) {
+	obs := Range(0, 100).Buffered(10)
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			values, err := collect(obs)
+			if err != nil || len(values) != 100 {
+				t.Errorf("got %d values, %v", len(values), err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestLiftDroppingCompleteFinishes(t *testing.T) {
+	obs := Just(1, 2).Lift(FunctionOperator(func(sub Subscriber, n Notification) {
+		if n.Type != OnComplete {
+			sub.Notify(n)
+		}
+	}))
+	done := make(chan interface{})
+	go func() {
+		defer close(done)
+		for range obs.Subscribe().Values() {
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("the subscription never finished after its source did")
+	}
+}
@@ -1,7 +1,9 @@
 package urx
 
 import (
-	"sync"
+	"context"
+	"iter"
+	"time"
 )
 
 type FunctionOperator func(Subscriber, Notification)
@@ -11,18 +13,69 @@ func (o FunctionOperator) Notify(s Subscriber, n Notification) {
 }
 
 type Observable interface {
-	Publish() PublishedObservable
+	Publish(scheduler ...Scheduler) PublishedObservable
+	Connectable(scheduler ...Scheduler) ConnectableObservable
+	Share() Observable
 	Lift(Operator) Observable
+	LiftFunc(OperatorFactory) Observable
 	Map(m func(interface{}) interface{}) Observable
 	Filter(func(interface{}) bool) Observable
 	Buffered(buffer int) Observable
+	Take(n int) Observable
+	TakeWhile(func(interface{}) bool) Observable
+	TakeUntil(other Observable) Observable
+	TakeLast(n int) Observable
+	Skip(n int) Observable
+	SkipWhile(func(interface{}) bool) Observable
+	SkipUntil(other Observable) Observable
+	SkipLast(n int) Observable
+	Scan(seed interface{}, acc func(acc, value interface{}) interface{}) Observable
+	Reduce(seed interface{}, acc func(acc, value interface{}) interface{}) Observable
+	Count() Observable
+	Min(less func(a, b interface{}) bool) Observable
+	Max(less func(a, b interface{}) bool) Observable
+	Sum() Observable
+	Average() Observable
+	DistinctUntilChanged(equal func(a, b interface{}) bool) Observable
+	DistinctUntilKeyChanged(key func(interface{}) interface{}) Observable
+	Distinct(key func(interface{}) interface{}, opts ...DistinctOptions) Observable
+	Debounce(d time.Duration, clock ...Clock) Observable
+	ThrottleFirst(d time.Duration, clock ...Clock) Observable
+	ThrottleLast(d time.Duration, clock ...Clock) Observable
+	Audit(d time.Duration, clock ...Clock) Observable
+	Sample(d time.Duration, clock ...Clock) Observable
+	SampleWith(notifier Observable) Observable
+	BufferCount(size, skip int) Observable
+	BufferTime(d time.Duration, clock ...Clock) Observable
+	BufferTimeOrCount(d time.Duration, max int, clock ...Clock) Observable
+	BufferWhen(closing Observable) Observable
+	WindowCount(size int) Observable
+	WindowTime(d time.Duration, clock ...Clock) Observable
+	WindowToggle(openings Observable, closing func(interface{}) Observable) Observable
+	WindowWhen(closing Observable) Observable
+	FlatMap(project func(interface{}) Observable, maxConcurrent ...int) Observable
+	ConcatMap(project func(interface{}) Observable) Observable
+	SwitchMap(project func(interface{}) Observable) Observable
+	ExhaustMap(project func(interface{}) Observable) Observable
+	WithLatestFrom(other Observable) Observable
 	Subscribe() Subscription
+	SubscribeContext(context.Context) Subscription
+	SubscribeWith(Observer) Subscription
+	SubscribeFunc(onNext func(interface{}), onError func(error), onComplete func()) Subscription
+	ForEach(func(interface{})) error
+	Wait() error
+	ObserveOn(Scheduler) Observable
+	SubscribeOn(Scheduler) Observable
+	ToSeq(context.Context) iter.Seq[interface{}]
+	ToSeqErr(context.Context) iter.Seq2[interface{}, error]
+	All() iter.Seq[interface{}]
 
 	getObs() privObservable
 }
 
 type PublishedObservable interface {
 	Observable
+	WithBackpressure(Backpressure) Observable
 	Unsubscribe()
 	IsSubscribed() bool
 	Add(CompleteHook)
@@ -33,32 +86,44 @@ type pObservable struct {
 }
 
 func (p pObservable) IsSubscribed() bool {
-	return p.privObservable.(*publishedObservable).IsSubscribed()
+	return p.published().IsSubscribed()
 }
 
 func (p pObservable) Unsubscribe() {
-	p.privObservable.(*publishedObservable).Unsubscribe()
+	p.published().Unsubscribe()
 }
 
-func (p pObservable) Publish() PublishedObservable {
+func (p pObservable) Publish(...Scheduler) PublishedObservable {
 	return p
 }
 
 func (p pObservable) Add(h CompleteHook) {
-	p.privObservable.(*publishedObservable).Add(h)
+	p.published().Add(h)
 }
 
 type bObservable struct {
 	privObservable privObservable
 }
 
-func (o bObservable) Publish() PublishedObservable {
-	o.privObservable = published(o.privObservable)
+// shares a single subscription to the source, made when the first subscriber arrives, between all
+// subscribers. The scheduler runs the goroutine which reads from the source and delivers to them
+func (o bObservable) Publish(scheduler ...Scheduler) PublishedObservable {
+	p := published(o.privObservable, schedulerOf(scheduler))
+	p.lazy = true
+	o.privObservable = p
 	return pObservable{o}
 }
 
+// applies the same operator to every subscription
 func (o bObservable) Lift(operator Operator) Observable {
-	return bObservable{o.privObservable.Lift(operator)}
+	return o.LiftFunc(func() Operator {
+		return operator
+	})
+}
+
+// applies a new operator, created by newOp, to every subscription
+func (o bObservable) LiftFunc(newOp OperatorFactory) Observable {
+	return bObservable{o.privObservable.Lift(newOp)}
 }
 
 func (o bObservable) Map(m func(interface{}) interface{}) Observable {
@@ -84,45 +149,42 @@ func (o bObservable) Filter(f func(interface{}) bool) Observable {
 }
 
 func (o bObservable) Buffered(buffer int) Observable {
-	type buffered struct {
-		to   Subscriber
-		body Notification
-	}
-
-	var c chan buffered
-	var l sync.RWMutex
-
-	pump := func() {
-		for msg := range c {
-			if msg.to.IsSubscribed() {
-				msg.to.Notify(msg.body)
-			} else {
-				return
+	return o.LiftFunc(func() Operator {
+		c := make(chan Notification, buffer)
+		done := make(chan interface{})
+		drained := make(chan interface{})
+		return FunctionOperator(func(sub Subscriber, n Notification) {
+			if n.Type == OnStart {
+				sub.Add(func() {
+					close(done)
+				})
+				go func() {
+					defer close(drained)
+					for {
+						select {
+						case n := <-c:
+							sub.Notify(n)
+							if n.Type == OnComplete {
+								return
+							}
+						case <-done:
+							return
+						}
+					}
+				}()
 			}
-		}
-	}
-
-	start := func() {
-		c = make(chan buffered, buffer)
-		go pump()
-	}
-
-	return o.Lift(FunctionOperator(func(sub Subscriber, n Notification) {
-		if n.Type == OnStart {
-			l.Lock()
-			l.Unlock()
-			start()
-		} else {
-			l.RLock()
-			defer l.RUnlock()
-		}
-		c <- buffered{sub, n}
-		if n.Type == OnComplete {
-			close(c)
-		}
-	}))
+			select {
+			case c <- n:
+			case <-done:
+			}
+			//the subscriber finishes when the source does, so everything must be delivered by then
+			if n.Type == OnComplete {
+				<-drained
+			}
+		})
+	})
 }
 
 func (o bObservable) Subscribe() Subscription {
-	return wrappedSubscription{sub: o.privObservable.privSubscribe()}.init()
+	return wrapSubscription(o.privObservable.privSubscribe())
 }
@@ -0,0 +1,72 @@
+package urx
+
+type FunctionObserver func(Notification)
+
+func (o FunctionObserver) Notify(n Notification) {
+	o(n)
+}
+
+// subscribes to the observable and delivers every notification to the observer from a
+// separate goroutine. An OnError is the last notification the observer receives.
+// The returned subscription should only be used to unsubscribe.
+func (o bObservable) SubscribeWith(observer Observer) Subscription {
+	sub := o.Subscribe()
+	events := sub.Events()
+	go func() {
+		terminated := false
+		for n := range events {
+			if terminated {
+				continue
+			}
+			observer.Notify(n)
+			if n.Type == OnError {
+				terminated = true
+				sub.Unsubscribe()
+			}
+		}
+	}()
+	return sub
+}
+
+// subscribes to the observable with callbacks, any of which may be nil
+func (o bObservable) SubscribeFunc(onNext func(interface{}), onError func(error), onComplete func()) Subscription {
+	return o.SubscribeWith(FunctionObserver(func(n Notification) {
+		switch n.Type {
+		case OnNext:
+			if onNext != nil {
+				onNext(n.Body)
+			}
+		case OnError:
+			if onError != nil {
+				onError(n.Error())
+			}
+		case OnComplete:
+			if onComplete != nil {
+				onComplete()
+			}
+		}
+	}))
+}
+
+// calls f with every value, blocking until the observable completes. The error that
+// terminated the observable is returned, or nil if it completed normally
+func (o bObservable) ForEach(f func(interface{})) error {
+	sub := o.Subscribe()
+	for n := range sub.Events() {
+		switch n.Type {
+		case OnNext:
+			if f != nil {
+				f(n.Body)
+			}
+		case OnError:
+			sub.Unsubscribe()
+			return n.Error()
+		}
+	}
+	return nil
+}
+
+// blocks until the observable terminates, discarding values
+func (o bObservable) Wait() error {
+	return o.ForEach(nil)
+}
@@ -1,51 +1,76 @@
 package urx
 
-import (
-	"math/rand"
-	"sync"
-)
+import "sync"
 
 type publishedObservable struct {
-	completed   bool
-	source      privObservable
+	completed bool
+	source    privObservable
+	scheduler Scheduler
+	//the current connection to the source, guarded by the targetMutex
 	sub         privSubscription
 	targetMutex sync.RWMutex
-	targets     map[*simpleSubscriber]*simpleSubscriber
+	targets     map[*simpleSubscriber]*targetQueue
+	//connect to the source when the first target subscribes
+	lazy bool
+	//when set, record sees every notification before it is delivered and replay returns what a new
+	//target should receive before live notifications. Both are called with the targetMutex held
+	record func(Notification)
+	replay func() []Notification
 }
 
 func (obs *publishedObservable) privSubscribe() privSubscription {
+	return obs.subscribeTarget(Backpressure{})
+}
+
+func (obs *publishedObservable) subscribeTarget(bp Backpressure) privSubscription {
 	obs.targetMutex.Lock()
+	var replay []Notification
+	if obs.replay != nil {
+		replay = obs.replay()
+	}
 	if obs.completed {
+		obs.targetMutex.Unlock()
+		if obs.replay == nil {
+			replay = []Notification{Complete()}
+		}
 		newTarget := initSimpleSubscriber()
-		go func() {
-			newTarget.Notify(Start())
-			newTarget.Notify(Complete())
-		}()
+		go newTarget.notifyAll(append([]Notification{Start()}, replay...))
 		return newTarget
 	}
-	obs.initSubIfNeeded()
+	if obs.lazy {
+		obs.connectLocked()
+	}
 
 	newTarget := initSimpleSubscriber()
 	newTarget.extraLockers = append(newTarget.extraLockers, &obs.targetMutex)
-	obs.targets[newTarget] = newTarget
-	go newTarget.Notify(Start())
+	queue := newTargetQueue(newTarget, bp)
+	obs.targets[newTarget] = queue
+	//live notifications queue up while the target gets its Start and replay
+	go queue.deliver(append([]Notification{Start()}, replay...))
 	newTarget.Add(obs.removeTargetHook(newTarget))
 	obs.targetMutex.Unlock()
 	return newTarget
 }
 
 func (obs *publishedObservable) Unsubscribe() {
-	if obs.sub != nil {
-		obs.sub.Unsubscribe()
-	}
+	obs.targetMutex.Lock()
+	sub := obs.sub
 	obs.sub = nil
+	obs.targetMutex.Unlock()
+	if sub != nil {
+		sub.Unsubscribe()
+	}
 }
 
 func (obs *publishedObservable) IsSubscribed() bool {
+	obs.targetMutex.RLock()
+	defer obs.targetMutex.RUnlock()
 	return obs.sub != nil && obs.sub.IsSubscribed()
 }
 
 func (obs *publishedObservable) Add(h CompleteHook) {
+	obs.targetMutex.RLock()
+	defer obs.targetMutex.RUnlock()
 	if obs.sub == nil {
 		panic("cannot add while not subscribed")
 	}
@@ -53,81 +78,85 @@ func (obs *publishedObservable) Add(h CompleteHook) {
 	obs.sub.Add(h)
 }
 
-func (obs *publishedObservable) initSubIfNeeded() {
+// connects to the source unless already connected, returning the connection.
+// Once the source has completed the connection returned is already finished
+func (obs *publishedObservable) connect() Connection {
+	obs.targetMutex.Lock()
+	defer obs.targetMutex.Unlock()
+	return obs.connectLocked()
+}
+
+func (obs *publishedObservable) connectLocked() Connection {
+	if obs.completed {
+		return finishedSubscription()
+	}
 	if obs.sub == nil {
-		obs.sub = obs.source.privSubscribe()
-		go obs.pump()
+		sub := obs.source.privSubscribe()
+		obs.sub = sub
+		obs.scheduler.Schedule(func() {
+			obs.pump(sub)
+		})
 	}
+	return obs.sub
 }
 
-func (obs *publishedObservable) Lift(op Operator) privObservable {
-	return &liftedObservable{source: obs, op: op}
+// lets a completed observable which is no longer connected be connected again
+func (obs *publishedObservable) reset() {
+	obs.targetMutex.Lock()
+	defer obs.targetMutex.Unlock()
+	if obs.sub == nil {
+		obs.completed = false
+	}
+}
+
+func (obs *publishedObservable) Lift(newOp OperatorFactory) privObservable {
+	return &liftedObservable{source: obs, newOp: newOp}
 }
 
-func (obs *publishedObservable) pump() {
-	for e := range obs.sub.Events() {
+func (obs *publishedObservable) pump(sub privSubscription) {
+	for e := range sub.Events() {
 		if e.Type == OnStart {
 			continue
 		}
 		obs.pumpNotification(e)
 		if e.Type == OnComplete {
-			obs.completed = true
 			break
 		}
 	}
-	obs.Unsubscribe()
+	obs.targetMutex.Lock()
+	if obs.sub == sub {
+		obs.sub = nil
+	}
+	obs.targetMutex.Unlock()
+	sub.Unsubscribe()
 }
 
 func (obs *publishedObservable) pumpNotification(n Notification) {
 	obs.targetMutex.RLock()
-	//this is designed this way such that we can send notifications to all listeners as they become ready
-	//first, create all the select cases
-	targets := make([]*simpleSubscriber, 0, len(obs.targets))
-
-	//then create a reflect.Value from the notification we're sending
-	//go through all the targets
-	for i := range obs.targets {
-		//and for this target
-		target := obs.targets[i]
-		target.RLock()
-		if target.IsSubscribed() {
-			//add it to our targets
-			targets = append(targets, target)
-		} else {
-			target.RUnlock()
-		}
+	//the pump is the only writer of these, and new targets are only added while holding the write lock
+	if obs.record != nil {
+		obs.record(n)
+	}
+	if n.Type == OnComplete {
+		obs.completed = true
+	}
+	queues := make([]*targetQueue, 0, len(obs.targets))
+	for _, queue := range obs.targets {
+		queues = append(queues, queue)
 	}
 	obs.targetMutex.RUnlock()
-	//and then iterate through select cases until we're done
-	for len(targets) > 0 {
-		//get a random target
-		i := rand.Intn(len(targets))
-		target := targets[i]
-		//remove it from the targets collection
-		targets = append(targets[:i], targets[i+1:]...)
-		//select from (send, unsub, none-ready)
-		var unsubbed bool
-		select {
-		case target.out <- n:
-		case <-target.unsub:
-			unsubbed = true
-		default:
-			//if nothing is ready, re-prepare the target for later, and move on
-			targets = append(targets, target)
-			continue
-		}
-		//we reach here in every case except when we could not send or read unsub (default)
-		//we're done with the lock
-		target.RUnlock()
-		//if it's an OnComplete, we're ready to call our hooks and shut down
-		if n.Type == OnComplete && !unsubbed {
-			target.handleComplete()
-		}
+	//each target has its own goroutine delivering from its queue, so this only waits on
+	//targets whose policy is to block while their queue is full
+	for _, queue := range queues {
+		queue.push(n)
 	}
 }
 
 func (obs *publishedObservable) removeTargetHook(target *simpleSubscriber) func() {
 	return func() {
-		delete(obs.targets, target)
+		if queue, ok := obs.targets[target]; ok {
+			queue.close()
+			delete(obs.targets, target)
+		}
 	}
 }
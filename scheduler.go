@@ -0,0 +1,148 @@
+package urx
+
+import "sync"
+
+// A Scheduler decides where (and when) a task runs. Schedulers only control the functions given to
+// Create (and the constructors built on it), the goroutine a published observable reads its source on,
+// and delivery with ObserveOn. Every other goroutine urx starts is its own: each subscription to a
+// lifted observable (Map, Filter, Take, ...) moves notifications through its operator on a new goroutine,
+// and a subscription's channels are fed by another. These block for as long as the subscription lasts,
+// so running them on a bounded pool could starve it
+type Scheduler interface {
+	Schedule(task func())
+}
+
+var (
+	// runs tasks on the calling goroutine. This suits ObserveOn, but it cannot run an observable's
+	// function (with Create, SubscribeOn, Publish or Connectable), which panic if given it
+	ImmediateScheduler Scheduler = immediateScheduler{}
+	// runs every task on a new goroutine, which is how observables are subscribed by default
+	GoroutineScheduler Scheduler = goroutineScheduler{}
+)
+
+type immediateScheduler struct{}
+
+func (immediateScheduler) Schedule(task func()) {
+	task()
+}
+
+type goroutineScheduler struct{}
+
+func (goroutineScheduler) Schedule(task func()) {
+	go task()
+}
+
+// returns the first of the optional schedulers passed to a constructor, or the GoroutineScheduler
+func schedulerOf(schedulers []Scheduler) Scheduler {
+	if len(schedulers) > 0 && schedulers[0] != nil {
+		return producerScheduler(schedulers[0])
+	}
+	return GoroutineScheduler
+}
+
+// checks a scheduler can run an observable's function. Subscribe returns before anything reads
+// the subscription, so a function run on the subscribing goroutine would block it forever
+func producerScheduler(s Scheduler) Scheduler {
+	if s == ImmediateScheduler {
+		panic("urx: the ImmediateScheduler cannot run an observable's function, it would deadlock Subscribe")
+	}
+	return s
+}
+
+// A PoolScheduler runs tasks in the order they were scheduled on a fixed number of goroutines
+type PoolScheduler struct {
+	mutex  sync.Mutex
+	cond   *sync.Cond
+	queue  []func()
+	closed bool
+}
+
+// creates a scheduler which runs at most workers tasks at once
+func NewPoolScheduler(workers int) *PoolScheduler {
+	p := &PoolScheduler{}
+	p.cond = sync.NewCond(&p.mutex)
+	for i := 0; i < workers; i++ {
+		go p.work()
+	}
+	return p
+}
+
+// creates a scheduler which runs tasks one after another on a single goroutine
+func NewEventLoopScheduler() *PoolScheduler {
+	return NewPoolScheduler(1)
+}
+
+func (p *PoolScheduler) Schedule(task func()) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.closed {
+		panic("urx: task scheduled on a closed PoolScheduler")
+	}
+	p.queue = append(p.queue, task)
+	p.cond.Signal()
+}
+
+// stops the workers once the tasks already scheduled have run
+func (p *PoolScheduler) Close() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.closed = true
+	p.cond.Broadcast()
+}
+
+func (p *PoolScheduler) work() {
+	for {
+		p.mutex.Lock()
+		for len(p.queue) == 0 && !p.closed {
+			p.cond.Wait()
+		}
+		if len(p.queue) == 0 {
+			p.mutex.Unlock()
+			return
+		}
+		task := p.queue[0]
+		p.queue = p.queue[1:]
+		p.mutex.Unlock()
+		task()
+	}
+}
+
+// delivers notifications to subscribers on the scheduler, one at a time and in order. Only the delivery
+// runs on the scheduler, the observable is subscribed to as usual
+func (o bObservable) ObserveOn(s Scheduler) Observable {
+	return Create(func(sub Subscriber) {
+		inner := o.Subscribe()
+		sub.Add(inner.Unsubscribe)
+		for n := range inner.Events() {
+			if n.Type == OnStart {
+				continue
+			}
+			done := make(chan interface{})
+			s.Schedule(func() {
+				defer close(done)
+				sub.Notify(n)
+			})
+			<-done
+		}
+	})
+}
+
+// runs the functions which produce the observable's values on the scheduler when it is subscribed to.
+// Published observables share a single subscription to their source, so they are unaffected. Lifted
+// operators still run on their own goroutines, and operators which subscribe to other observables
+// (Debounce, the Buffer and Window operators, FlatMap, Zip, ...) run their own function on the scheduler,
+// but subscribe to those observables with the schedulers they were created with
+func (o bObservable) SubscribeOn(s Scheduler) Observable {
+	return bObservable{subscribeOn(o.privObservable, producerScheduler(s))}
+}
+
+func subscribeOn(obs privObservable, s Scheduler) privObservable {
+	switch obs := obs.(type) {
+	case simpleObservable:
+		obs.scheduler = s
+		return obs
+	case *liftedObservable:
+		return &liftedObservable{subscribeOn(obs.source, s), obs.newOp}
+	}
+	return obs
+}
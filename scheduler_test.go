@@ -0,0 +1,93 @@
+package urx
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPoolScheduler(t *testing.T) {
+	pool := NewPoolScheduler(3)
+	defer pool.Close()
+
+	var running, most int32
+	var wg sync.WaitGroup
+	wg.Add(20)
+	for i := 0; i < 20; i++ {
+		pool.Schedule(func() {
+			defer wg.Done()
+			now := atomic.AddInt32(&running, 1)
+			for {
+				prev := atomic.LoadInt32(&most)
+				if now <= prev || atomic.CompareAndSwapInt32(&most, prev, now) {
+					break
+				}
+			}
+			<-time.After(time.Millisecond * 5)
+			atomic.AddInt32(&running, -1)
+		})
+	}
+	wg.Wait()
+	if most > 3 {
+		t.Errorf("%d tasks ran at once on a pool of 3", most)
+	}
+}
+
+func TestObserveOn(t *testing.T) {
+	pool := NewPoolScheduler(4)
+	defer pool.Close()
+
+	//even with several workers, notifications must arrive in order
+	if i := verifyObs(t, createChanObs(10, time.Millisecond).ObserveOn(pool)); i != 10 {
+		t.Errorf("expected 10 values but got %d", i)
+	}
+}
+
+func TestSubscribeOn(t *testing.T) {
+	loop := NewEventLoopScheduler()
+	defer loop.Close()
+
+	done := make(chan interface{})
+	loop.Schedule(func() {
+		<-done
+	})
+	obs := Create(func(sub Subscriber) {
+		sub.Notify(Next(0))
+		sub.Notify(Complete())
+	}).SubscribeOn(loop)
+
+	values := obs.Subscribe().Values()
+	select {
+	case <-values:
+		t.Fatal("the observable was subscribed to before the event loop was free")
+	case <-time.After(time.Millisecond * 20):
+	}
+	close(done)
+	if v := <-values; v != 0 {
+		t.Errorf("expected 0 but got %v", v)
+	}
+}
+
+func TestImmediateSchedulerRejected(t *testing.T) {
+	for name, f := range map[string]func(){
+		"Create": func() {
+			Create(func(Subscriber) {}, ImmediateScheduler)
+		},
+		"SubscribeOn": func() {
+			Just(1).SubscribeOn(ImmediateScheduler)
+		},
+		"Publish": func() {
+			Just(1).Publish(ImmediateScheduler)
+		},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s accepted the ImmediateScheduler", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
@@ -0,0 +1,82 @@
+package urx
+
+import (
+	"context"
+	"iter"
+)
+
+// the values emitted by FromSeq2
+type KeyValue struct {
+	Key, Value interface{}
+}
+
+// emits every value of the sequence and then completes. The sequence stops being pulled
+// as soon as the subscriber is done
+func FromSeq[T any](seq iter.Seq[T]) Observable {
+	return Create(func(sub Subscriber) {
+		for v := range seq {
+			if !sub.IsSubscribed() {
+				return
+			}
+			sub.Notify(Next(v))
+		}
+		sub.Notify(Complete())
+	})
+}
+
+// emits a KeyValue for every pair in the sequence and then completes
+func FromSeq2[K, V any](seq iter.Seq2[K, V]) Observable {
+	return Create(func(sub Subscriber) {
+		for k, v := range seq {
+			if !sub.IsSubscribed() {
+				return
+			}
+			sub.Notify(Next(KeyValue{k, v}))
+		}
+		sub.Notify(Complete())
+	})
+}
+
+// returns a sequence of the observable's values, which ends when the observable terminates
+// or the context is done. An error (or the context ending) ends the sequence just like completion
+// does, so use ToSeqErr to tell them apart. Every iteration subscribes anew, and breaking out of the loop unsubscribes
+func (o bObservable) ToSeq(ctx context.Context) iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		for v, err := range o.ToSeqErr(ctx) {
+			if err != nil || !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// like ToSeq, but an error from the observable, or the context's error if it is done first, is
+// yielded (with a nil value) as the last pair of the sequence. Every other pair has a nil error
+func (o bObservable) ToSeqErr(ctx context.Context) iter.Seq2[interface{}, error] {
+	return func(yield func(interface{}, error) bool) {
+		sub := o.SubscribeContext(ctx)
+		defer sub.Unsubscribe()
+		for n := range sub.Events() {
+			switch n.Type {
+			case OnNext:
+				if !yield(n.Body, nil) {
+					return
+				}
+			case OnError:
+				yield(nil, n.Error())
+				return
+			case OnComplete:
+				return
+			}
+		}
+		//the events only end without a terminal notification when the context unsubscribed
+		if err := ctx.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+// returns a sequence of the observable's values, see ToSeq
+func (o bObservable) All() iter.Seq[interface{}] {
+	return o.ToSeq(context.Background())
+}
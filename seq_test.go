@@ -0,0 +1,114 @@
+package urx
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFromSeq(t *testing.T) {
+	var pulled int32
+	seq := func(yield func(int) bool) {
+		for i := 0; ; i++ {
+			atomic.AddInt32(&pulled, 1)
+			if !yield(i) {
+				return
+			}
+		}
+	}
+
+	sub := FromSeq(seq).Subscribe()
+	values := sub.Values()
+	for i := 0; i < 3; i++ {
+		if v := <-values; v != i {
+			t.Errorf("expected %d but got %v", i, v)
+		}
+	}
+	sub.Unsubscribe()
+	atUnsubscribe := atomic.LoadInt32(&pulled)
+	for range values {
+	}
+	<-time.After(time.Millisecond * 10)
+	//the producer may pull once more before it sees it has been unsubscribed
+	if n := atomic.LoadInt32(&pulled) - atUnsubscribe; n > 1 {
+		t.Errorf("the sequence was pulled %d times after unsubscription", n)
+	}
+}
+
+func TestFromSeq2(t *testing.T) {
+	values, _ := collect(FromSeq2(maps.All(map[string]int{"a": 1})))
+	if len(values) != 1 || values[0] != (KeyValue{"a", 1}) {
+		t.Errorf("unexpected values %v", values)
+	}
+}
+
+func TestToSeq(t *testing.T) {
+	got := slices.Collect(Range(0, 5).All())
+	if len(got) != 5 || got[4] != 4 {
+		t.Errorf("unexpected values %v", got)
+	}
+
+	var hooked bool
+	obs := Create(func(sub Subscriber) {
+		sub.Add(func() {
+			hooked = true
+		})
+		for i := 0; sub.IsSubscribed(); i++ {
+			sub.Notify(Next(i))
+		}
+	})
+	for v := range obs.ToSeq(context.Background()) {
+		if v == 2 {
+			break
+		}
+	}
+	if !hooked {
+		t.Error("breaking out of the loop did not unsubscribe")
+	}
+}
+
+func TestToSeqErr(t *testing.T) {
+	testErr := errors.New("test")
+	var values []interface{}
+	var errs []error
+	obs := Create(func(sub Subscriber) {
+		sub.Notify(Next(1))
+		sub.Notify(Next(2))
+		sub.Notify(Error(testErr))
+	})
+	for v, err := range obs.ToSeqErr(context.Background()) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		values = append(values, v)
+	}
+	if len(values) != 2 || len(errs) != 1 || errs[0] != testErr {
+		t.Errorf("expected two values then the error, got %v and %v", values, errs)
+	}
+}
+
+func TestToSeqErrContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var values []interface{}
+	var errs []error
+	obs := Create(func(sub Subscriber) {
+		sub.Notify(Next(1))
+	})
+	for v, err := range obs.ToSeqErr(ctx) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		values = append(values, v)
+		cancel()
+	}
+	if len(values) != 1 || len(errs) != 1 || errs[0] != context.Canceled {
+		t.Errorf("expected a value then the context's error, got %v and %v", values, errs)
+	}
+}
@@ -4,7 +4,8 @@ import "sync"
 
 // The simple observable is simply a function which takes a subscriber and provides it with data
 type simpleObservable struct {
-	onSub *func(Subscriber)
+	onSub     *func(Subscriber)
+	scheduler Scheduler
 }
 
 // this creates a subscription (by calling the simpleObservable function immediately)
@@ -13,16 +14,16 @@ func (obs simpleObservable) privSubscribe() privSubscription {
 	outSub := initSimpleSubscriber()
 	outSub.mangleError = true
 	f := *obs.onSub
-	go func() {
+	obs.scheduler.Schedule(func() {
 		outSub.Notify(Start())
 		f(outSub)
-	}()
+	})
 	return outSub
 }
 
 // applies an operator to the observable such that subscriptions to the resulting observable flow through the operator
-func (obs simpleObservable) Lift(op Operator) (newObs privObservable) {
-	newObs = &liftedObservable{obs, op}
+func (obs simpleObservable) Lift(newOp OperatorFactory) (newObs privObservable) {
+	newObs = &liftedObservable{obs, newOp}
 	return
 }
 
@@ -113,6 +114,12 @@ func (sub *simpleSubscriber) Notify(n Notification) {
 	}
 }
 
+func (sub *simpleSubscriber) notifyAll(ns []Notification) {
+	for _, n := range ns {
+		sub.Notify(n)
+	}
+}
+
 func (sub *simpleSubscriber) rawSend(n Notification) bool {
 	select {
 	case sub.out <- n:
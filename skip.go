@@ -0,0 +1,73 @@
+package urx
+
+import "sync/atomic"
+
+// ignores the first n values
+func (o bObservable) Skip(n int) Observable {
+	return o.LiftFunc(func() Operator {
+		skipped := 0
+		return FunctionOperator(func(sub Subscriber, not Notification) {
+			if not.Type == OnNext && skipped < n {
+				skipped++
+				return
+			}
+			sub.Notify(not)
+		})
+	})
+}
+
+// ignores values while they satisfy the predicate, emitting everything from the first which does not
+func (o bObservable) SkipWhile(pred func(interface{}) bool) Observable {
+	return o.LiftFunc(func() Operator {
+		skipping := true
+		return FunctionOperator(func(sub Subscriber, n Notification) {
+			if n.Type == OnNext && skipping {
+				if pred(n.Body) {
+					return
+				}
+				skipping = false
+			}
+			sub.Notify(n)
+		})
+	})
+}
+
+// ignores values until the other observable emits a value
+func (o bObservable) SkipUntil(other Observable) Observable {
+	return o.LiftFunc(func() Operator {
+		var open atomic.Bool
+		return FunctionOperator(func(sub Subscriber, n Notification) {
+			switch n.Type {
+			case OnStart:
+				sub.Notify(n)
+				watch(other, sub, func(Notification) {
+					open.Store(true)
+				})
+			case OnNext:
+				if open.Load() {
+					sub.Notify(n)
+				}
+			default:
+				sub.Notify(n)
+			}
+		})
+	})
+}
+
+// ignores the last n values, holding each value back until n more have arrived
+func (o bObservable) SkipLast(n int) Observable {
+	return o.LiftFunc(func() Operator {
+		var held []interface{}
+		return FunctionOperator(func(sub Subscriber, not Notification) {
+			if not.Type != OnNext || n <= 0 {
+				sub.Notify(not)
+				return
+			}
+			held = append(held, not.Body)
+			if len(held) > n {
+				sub.Notify(Next(held[0]))
+				held = held[1:]
+			}
+		})
+	})
+}
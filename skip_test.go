@@ -0,0 +1,16 @@
+package urx
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSkipPerSubscription(t *testing.T) {
+	obs := Range(0, 4).Skip(2).SkipLast(1)
+	for i := 0; i < 2; i++ {
+		values, _ := collect(obs)
+		if !reflect.DeepEqual(values, []interface{}{2}) {
+			t.Errorf("subscription %d got %v", i, values)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package urx
+
+// emits each of the values and then completes
+func Just(values ...interface{}) Observable {
+	return FromSlice(values)
+}
+
+// emits each value in the slice and then completes
+func FromSlice(values []interface{}) Observable {
+	return Create(func(sub Subscriber) {
+		for _, v := range values {
+			if !sub.IsSubscribed() {
+				return
+			}
+			sub.Notify(Next(v))
+		}
+		sub.Notify(Complete())
+	})
+}
+
+// emits count ints counting up from start and then completes
+func Range(start, count int) Observable {
+	return Create(func(sub Subscriber) {
+		for i := start; i < start+count; i++ {
+			if !sub.IsSubscribed() {
+				return
+			}
+			sub.Notify(Next(i))
+		}
+		sub.Notify(Complete())
+	})
+}
+
+// completes without emitting anything
+func Empty() Observable {
+	return Create(func(sub Subscriber) {
+		sub.Notify(Complete())
+	})
+}
+
+// never emits anything, including completion
+func Never() Observable {
+	return Create(func(Subscriber) {})
+}
+
+// emits the error without emitting any values
+func Throw(err error) Observable {
+	return Create(func(sub Subscriber) {
+		sub.Notify(Error(err))
+	})
+}
+
+// calls f to create a new observable for every subscription
+func Defer(f func() Observable) Observable {
+	return Create(func(sub Subscriber) {
+		pipe(f(), sub)
+	})
+}
+
+// subscribes to obs and forwards everything but OnStart to sub until either of them is done
+func pipe(obs Observable, sub Subscriber) {
+	inner := obs.Subscribe()
+	sub.Add(inner.Unsubscribe)
+	for n := range inner.Events() {
+		if n.Type == OnStart {
+			continue
+		}
+		if !sub.IsSubscribed() {
+			return
+		}
+		sub.Notify(n)
+	}
+}
@@ -0,0 +1,57 @@
+package urx
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func collect(obs Observable) (values []interface{}, err error) {
+	err = obs.ForEach(func(v interface{}) {
+		values = append(values, v)
+	})
+	return
+}
+
+func TestStatic(t *testing.T) {
+	testErr := fmt.Errorf("test")
+	calls := 0
+	deferred := Defer(func() Observable {
+		calls++
+		return Just(calls)
+	})
+
+	cases := []struct {
+		name   string
+		obs    Observable
+		values []interface{}
+		err    error
+	}{
+		{"Just", Just(1, "two", 3), []interface{}{1, "two", 3}, nil},
+		{"FromSlice", FromSlice([]interface{}{4, 5}), []interface{}{4, 5}, nil},
+		{"Range", Range(3, 4), []interface{}{3, 4, 5, 6}, nil},
+		{"Empty", Empty(), nil, nil},
+		{"Throw", Throw(testErr), nil, testErr},
+		{"Defer", deferred, []interface{}{1}, nil},
+		{"Defer again", deferred, []interface{}{2}, nil},
+	}
+	for _, c := range cases {
+		values, err := collect(c.obs)
+		if !reflect.DeepEqual(values, c.values) || err != c.err {
+			t.Errorf("%s: expected %v and %v but got %v and %v", c.name, c.values, c.err, values, err)
+		}
+	}
+}
+
+func TestRangeUnsubscribe(t *testing.T) {
+	sub := Range(0, 1<<30).Subscribe()
+	values := sub.Values()
+	<-values
+	<-values
+	sub.Unsubscribe()
+	for range values {
+	}
+	if sub.IsSubscribed() {
+		t.Error("range is still subscribed")
+	}
+}
@@ -1,22 +1,79 @@
 package urx
 
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// returned when posting to a subject which has already errored or completed
+var ErrSubjectTerminated = errors.New("urx: the subject has already terminated")
+
+var errSubjectFull = errors.New("urx: the subject's buffer is full")
+
+type SubjectOptions struct {
+	// how many notifications can be posted before posting blocks (or TryPost fails)
+	// while subscribers catch up. By default posting blocks until the previous notification is taken
+	Buffer int
+}
+
 type simpleSubject struct {
 	source chan Notification
 	obs    PublishedObservable
+	*postState
+}
+
+type postState struct {
+	//posts hold a read lock, terminal posts hold the write lock
+	lock       sync.RWMutex
+	terminated bool
+	posted     func(Notification)
+}
+
+// what makes one kind of subject different from another
+type subjectConfig struct {
+	SubjectOptions
+	// see publishedObservable
+	record func(Notification)
+	replay func() []Notification
+	// passes posted notifications on to the published observable, a single goroutine calls this
+	forward func(Subscriber, Notification)
+	// called with each notification once it has been posted
+	posted func(Notification)
+}
+
+func NewPublishSubject(opts ...SubjectOptions) Subject {
+	return newSubject(subjectConfig{SubjectOptions: subjectOptionsOf(opts)})
+}
+
+func subjectOptionsOf(opts []SubjectOptions) SubjectOptions {
+	if len(opts) > 0 {
+		return opts[0]
+	}
+	return SubjectOptions{}
 }
 
-func NewPublishSubject() Subject {
-	var out simpleSubject
-	out.source = make(chan Notification)
-	out.obs = Create(func(subscriber Subscriber) {
+func newSubject(config subjectConfig) simpleSubject {
+	out := simpleSubject{postState: &postState{posted: config.posted}}
+	out.source = make(chan Notification, config.Buffer)
+	forward := config.forward
+	if forward == nil {
+		forward = Subscriber.Notify
+	}
+	conn := Create(func(subscriber Subscriber) {
 		for n := range out.source {
-			subscriber.Notify(n)
-			if n.Type == OnComplete {
-				close(out.source)
+			forward(subscriber, n)
+			if n.Type == OnComplete || n.Type == OnError {
+				return
 			}
 		}
-	}).Publish()
-	out.obs.getObs().(*publishedObservable).initSubIfNeeded()
+	}).Connectable()
+	published := conn.getObs().(*publishedObservable)
+	published.record = config.record
+	published.replay = config.replay
+	conn.Connect()
+	out.obs = conn
 	return out
 }
 
@@ -32,8 +89,54 @@ func (s simpleSubject) Complete() {
 	s.Post(Complete())
 }
 
-func (s simpleSubject) Post(n Notification) {
-	s.source <- n
+// posts the notification, blocking while the buffer is full.
+// ErrSubjectTerminated is returned if the subject has already errored or completed
+func (s simpleSubject) Post(n Notification) error {
+	return s.send(context.Background(), n, false)
+}
+
+// posts the notification, returning false if it would block or the subject has terminated
+func (s simpleSubject) TryPost(n Notification) bool {
+	return s.send(context.Background(), n, true) == nil
+}
+
+// posts the notification, blocking while the buffer is full until the context is done
+func (s simpleSubject) PostContext(ctx context.Context, n Notification) error {
+	return s.send(ctx, n, false)
+}
+
+func (s simpleSubject) send(ctx context.Context, n Notification, try bool) error {
+	terminal := n.Type == OnComplete || n.Type == OnError
+	if terminal {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	} else {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+	if s.terminated {
+		return ErrSubjectTerminated
+	}
+	if try {
+		select {
+		case s.source <- n:
+		default:
+			return errSubjectFull
+		}
+	} else {
+		select {
+		case s.source <- n:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if terminal {
+		s.terminated = true
+	}
+	if s.posted != nil {
+		s.posted(n)
+	}
+	return nil
 }
 
 func (s simpleSubject) Subscribe() Subscription {
@@ -43,3 +146,195 @@ func (s simpleSubject) Subscribe() Subscription {
 func (s simpleSubject) AsObservable() Observable {
 	return s.obs
 }
+
+type BehaviorSubject interface {
+	Subject
+	// the most recent value passed to Next, or the initial value
+	Value() interface{}
+}
+
+type behaviorSubject struct {
+	simpleSubject
+	*behaviorState
+}
+
+type behaviorState struct {
+	mutex sync.RWMutex
+	value interface{}
+	//what has been delivered to subscribers so far, which is replayed to new ones
+	latest   interface{}
+	terminal []Notification
+}
+
+// creates a subject which emits its latest value (starting with initial) to every new subscriber,
+// or how it terminated if it already has
+func NewBehaviorSubject(initial interface{}, opts ...SubjectOptions) BehaviorSubject {
+	state := &behaviorState{value: initial, latest: initial}
+	subj := newSubject(subjectConfig{
+		SubjectOptions: subjectOptionsOf(opts),
+		record:         state.record,
+		replay:         state.replay,
+		posted:         state.posted,
+	})
+	return behaviorSubject{subj, state}
+}
+
+func (s behaviorSubject) Value() interface{} {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.value
+}
+
+func (s *behaviorState) posted(n Notification) {
+	if n.Type == OnNext {
+		s.mutex.Lock()
+		s.value = n.Body
+		s.mutex.Unlock()
+	}
+}
+
+func (s *behaviorState) record(n Notification) {
+	switch {
+	case s.terminal != nil:
+	case n.Type == OnNext:
+		s.latest = n.Body
+	case n.Type == OnError:
+		s.terminal = []Notification{n, Complete()}
+	case n.Type == OnComplete:
+		s.terminal = []Notification{n}
+	}
+}
+
+func (s *behaviorState) replay() []Notification {
+	if s.terminal != nil {
+		return s.terminal
+	}
+	return []Notification{Next(s.latest)}
+}
+
+type ReplayOptions struct {
+	SubjectOptions
+	// the most values to replay, or 0 for no limit
+	Size int
+	// how long values are replayed for after they are emitted, or 0 for forever
+	Window time.Duration
+	// times the window, SystemClock by default
+	Clock Clock
+}
+
+type replayState struct {
+	ReplayOptions
+	buffer   []replayed
+	terminal []Notification
+}
+
+type replayed struct {
+	at time.Time
+	n  Notification
+}
+
+// creates a subject which replays the values it has emitted (limited by the options) and how it
+// terminated to every new subscriber before any live notifications
+func NewReplaySubject(opts ReplayOptions) Subject {
+	if opts.Clock == nil {
+		opts.Clock = SystemClock
+	}
+	state := &replayState{ReplayOptions: opts}
+	return newSubject(subjectConfig{
+		SubjectOptions: opts.SubjectOptions,
+		record:         state.record,
+		replay:         state.replay,
+	})
+}
+
+func (s *replayState) record(n Notification) {
+	switch {
+	case s.terminal != nil:
+	case n.Type == OnNext:
+		s.buffer = append(s.buffer, replayed{s.Clock.Now(), n})
+		s.trim()
+	case n.Type == OnError:
+		s.terminal = []Notification{n, Complete()}
+	case n.Type == OnComplete:
+		s.terminal = []Notification{n}
+	}
+}
+
+// drops the values which are outside the size or window
+func (s *replayState) trim() {
+	if s.Size > 0 && len(s.buffer) > s.Size {
+		s.buffer = s.buffer[len(s.buffer)-s.Size:]
+	}
+	if s.Window > 0 {
+		cutoff := s.Clock.Now().Add(-s.Window)
+		i := 0
+		for i < len(s.buffer) && s.buffer[i].at.Before(cutoff) {
+			i++
+		}
+		s.buffer = s.buffer[i:]
+	}
+}
+
+func (s *replayState) replay() []Notification {
+	s.trim()
+	out := make([]Notification, 0, len(s.buffer)+len(s.terminal))
+	for _, r := range s.buffer {
+		out = append(out, r.n)
+	}
+	return append(out, s.terminal...)
+}
+
+type asyncState struct {
+	last   *Notification
+	result []Notification
+	done   bool
+}
+
+// creates a subject which only emits the last value passed to Next, followed by completion, once
+// Complete is called. Subscribers arriving after that get the same result
+func NewAsyncSubject(opts ...SubjectOptions) Subject {
+	state := &asyncState{}
+	return newSubject(subjectConfig{
+		SubjectOptions: subjectOptionsOf(opts),
+		record:         state.record,
+		replay:         state.replay,
+		forward:        state.forward,
+	})
+}
+
+// values are held back until the subject completes
+func (s *asyncState) forward(sub Subscriber, n Notification) {
+	switch n.Type {
+	case OnNext:
+		s.last = &n
+		return
+	case OnComplete:
+		if s.last != nil {
+			sub.Notify(*s.last)
+		}
+	}
+	sub.Notify(n)
+}
+
+// only the result is ever delivered, so it is all that needs recording
+func (s *asyncState) record(n Notification) {
+	if s.done {
+		return
+	}
+	switch n.Type {
+	case OnNext:
+		s.result = []Notification{n}
+	case OnError:
+		s.result = []Notification{n}
+		s.done = true
+	case OnComplete:
+		s.done = true
+	}
+}
+
+func (s *asyncState) replay() []Notification {
+	if !s.done {
+		return s.result
+	}
+	return append(s.result[:len(s.result):len(s.result)], Complete())
+}
@@ -1,6 +1,7 @@
 package urx
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"testing"
@@ -49,3 +50,121 @@ func TestSubject(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestBehaviorSubject(t *testing.T) {
+	subj := NewBehaviorSubject(0)
+	first := subj.Subscribe()
+	if v := <-first.Values(); v != 0 {
+		t.Errorf("expected the initial value but got %v", v)
+	}
+	first.Unsubscribe()
+
+	early := subj.Subscribe().Values()
+	if v := <-early; v != 0 {
+		t.Errorf("expected the initial value but got %v", v)
+	}
+	subj.Next(1)
+	if v := <-early; v != 1 {
+		t.Errorf("expected 1 but got %v", v)
+	}
+	if v := subj.Value(); v != 1 {
+		t.Errorf("expected Value() to be 1 but got %v", v)
+	}
+
+	late := subj.Subscribe().Values()
+	if v := <-late; v != 1 {
+		t.Errorf("expected a late subscriber to get 1 but got %v", v)
+	}
+
+	subj.Error(fmt.Errorf("test"))
+	for range early {
+	}
+	if err := subj.AsObservable().Wait(); err == nil || err.Error() != "test" {
+		t.Errorf("expected the terminal error to be replayed but got %v", err)
+	}
+}
+
+func TestReplaySubject(t *testing.T) {
+	subj := NewReplaySubject(ReplayOptions{Size: 2})
+	early := subj.Subscribe()
+	go func() {
+		for i := 0; i < 3; i++ {
+			subj.Next(i)
+		}
+		subj.Complete()
+	}()
+	//once the early subscriber has everything, so does the replay buffer
+	for range early.Values() {
+	}
+
+	values, err := collect(subj.AsObservable())
+	if err != nil || len(values) != 2 || values[0] != 1 || values[1] != 2 {
+		t.Errorf("expected [1 2] to be replayed but got %v and %v", values, err)
+	}
+}
+
+func TestReplaySubjectWindow(t *testing.T) {
+	subj := NewReplaySubject(ReplayOptions{Window: time.Millisecond * 50})
+	subj.Next("old")
+	<-time.After(time.Millisecond * 100)
+	subj.Next("new")
+
+	sub := subj.Subscribe()
+	if v := <-sub.Values(); v != "new" {
+		t.Errorf("expected only new values to be replayed but got %v", v)
+	}
+	sub.Unsubscribe()
+}
+
+func TestAsyncSubject(t *testing.T) {
+	subj := NewAsyncSubject()
+	early := subj.Subscribe()
+	go func() {
+		for i := 0; i < 3; i++ {
+			subj.Next(i)
+		}
+		subj.Complete()
+	}()
+
+	var got []interface{}
+	for v := range early.Values() {
+		got = append(got, v)
+	}
+	late, err := collect(subj.AsObservable())
+	if len(got) != 1 || got[0] != 2 || err != nil || len(late) != 1 || late[0] != 2 {
+		t.Errorf("expected only the final value but got %v, then %v and %v", got, late, err)
+	}
+}
+
+func TestSubjectPost(t *testing.T) {
+	subj := NewPublishSubject(SubjectOptions{Buffer: 2})
+	//the first notification is taken straight away by the subject's pump, but then it waits on the subscriber
+	sub := subj.Subscribe()
+	values := sub.Values()
+	accepted := 0
+	for i := 0; i < 10; i++ {
+		if subj.TryPost(Next(i)) {
+			accepted++
+		}
+		<-time.After(time.Millisecond)
+	}
+	if accepted == 10 {
+		t.Error("TryPost never reported a full buffer")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+	defer cancel()
+	if err := subj.PostContext(ctx, Next(-1)); err != context.DeadlineExceeded {
+		t.Errorf("expected the deadline to be exceeded but got %v", err)
+	}
+
+	go subj.Complete()
+	for range values {
+	}
+	if err := subj.Post(Next(0)); err != ErrSubjectTerminated {
+		t.Errorf("expected ErrSubjectTerminated but got %v", err)
+	}
+	if subj.TryPost(Complete()) {
+		t.Error("TryPost succeeded after completion")
+	}
+}
@@ -1,9 +1,6 @@
 package urx
 
-import (
-	"sync"
-	"time"
-)
+import "sync"
 
 type Subscription interface {
 	Events() <-chan Notification
@@ -21,20 +18,25 @@ type wrappedSubscription struct {
 	error            chan error
 	pumping          bool
 	mutex            sync.RWMutex
+	//closed by Unsubscribe, after which nothing more is delivered
+	unsub     chan interface{}
+	unsubOnce sync.Once
 }
 
-func (s wrappedSubscription) init() *wrappedSubscription {
-	return &s
+func wrapSubscription(sub privSubscription) *wrappedSubscription {
+	return &wrappedSubscription{sub: sub, unsub: make(chan interface{})}
 }
 
-func (s *wrappedSubscription) pump() {
-pump_loop:
+// delivers the source's notifications until it ends, reporting false if that was because of Unsubscribe
+func (s *wrappedSubscription) pump() bool {
 	for e := range s.sub.Events() {
 		switch e.Type {
 		case OnStart:
 			if s.source != nil {
 				select {
 				case s.source <- e:
+				case <-s.unsub:
+					return false
 				}
 			}
 		case OnNext:
@@ -42,6 +44,8 @@ pump_loop:
 				select {
 				case s.source <- e:
 				case s.values <- e.Body:
+				case <-s.unsub:
+					return false
 				}
 			}
 		case OnError:
@@ -49,12 +53,25 @@ pump_loop:
 				select {
 				case s.source <- e:
 				case s.error <- e.Body.(error):
+				case <-s.unsub:
+					return false
 				}
 			}
 		case OnComplete:
-			break pump_loop
+			//unsubscribing makes the source complete too
+			return !s.unsubscribed()
 		}
 	}
+	return !s.unsubscribed()
+}
+
+func (s *wrappedSubscription) unsubscribed() bool {
+	select {
+	case <-s.unsub:
+		return true
+	default:
+		return false
+	}
 }
 
 func (s *wrappedSubscription) Events() <-chan Notification {
@@ -66,6 +83,9 @@ func (s *wrappedSubscription) Events() <-chan Notification {
 }
 
 func (s *wrappedSubscription) Unsubscribe() {
+	s.unsubOnce.Do(func() {
+		close(s.unsub)
+	})
 	s.sub.Unsubscribe()
 }
 
@@ -77,12 +97,12 @@ func (s *wrappedSubscription) initIfNeeded(allEvents bool) {
 		s.pumping = true
 		s.mutex.Unlock()
 		go func() {
-			s.pump()
-			if s.source != nil || s.complete != nil {
+			//the completion is held until it is read, unless the subscriber gives up by unsubscribing
+			if s.pump() && (s.source != nil || s.complete != nil) {
 				select {
 				case s.source <- Complete():
 				case s.complete <- nil:
-				case <-time.After(time.Millisecond * 100):
+				case <-s.unsub:
 				}
 			}
 			if s.error != nil {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"sync"
 	"testing"
+	"testing/synctest"
 	"time"
 )
 
@@ -44,3 +45,71 @@ func TestSubscription(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+func TestSubscribeFunc(t *testing.T) {
+	done := make(chan interface{})
+	sum := 0
+	createChanObs(5, time.Millisecond*5).SubscribeFunc(func(v interface{}) {
+		sum += v.(int)
+	}, func(err error) {
+		t.Errorf("unexpected error %v", err)
+	}, func() {
+		close(done)
+	})
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("onComplete was never called")
+	}
+	if sum != 10 {
+		t.Errorf("expected a sum of 10 but got %d", sum)
+	}
+}
+
+func TestSubscriptionHoldsComplete(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		//the completion waits for a slow reader, however long it takes
+		sub := Just(1).Subscribe()
+		events := sub.Events()
+		for _, expected := range []NotificationType{OnStart, OnNext} {
+			if n := <-events; n.Type != expected {
+				t.Fatalf("expected %v but got %v", expected, n)
+			}
+		}
+		time.Sleep(time.Hour)
+		if n, ok := <-events; !ok || n.Type != OnComplete {
+			t.Errorf("expected the completion but got %v, %v", n, ok)
+		}
+
+		//unsubscribing releases a subscription nobody is reading, which synctest.Test checks
+		sub = Range(0, 1000).Subscribe()
+		<-sub.Values()
+		sub.Unsubscribe()
+		sub = Just(1).Subscribe()
+		sub.Complete()
+		synctest.Wait()
+		sub.Unsubscribe()
+	})
+}
+
+func TestForEach(t *testing.T) {
+	testErr := fmt.Errorf("test")
+	obs := Create(func(sub Subscriber) {
+		sub.Notify(Next(1))
+		sub.Notify(Next(2))
+		sub.Notify(Error(testErr))
+	})
+
+	got := 0
+	err := obs.ForEach(func(interface{}) {
+		got++
+	})
+	if err != testErr || got != 2 {
+		t.Errorf("expected 2 values and the test error, got %d values and %v", got, err)
+	}
+
+	if err := createChanObs(3, time.Millisecond*5).Wait(); err != nil {
+		t.Errorf("expected a nil error but got %v", err)
+	}
+}
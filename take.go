@@ -0,0 +1,89 @@
+package urx
+
+// emits the first n values and then completes, unsubscribing from the source
+func (o bObservable) Take(n int) Observable {
+	if n <= 0 {
+		return Empty()
+	}
+	return o.LiftFunc(func() Operator {
+		taken := 0
+		return FunctionOperator(func(sub Subscriber, not Notification) {
+			sub.Notify(not)
+			if not.Type == OnNext {
+				taken++
+				if taken == n {
+					sub.Notify(Complete())
+				}
+			}
+		})
+	})
+}
+
+// emits values while they satisfy the predicate, completing at the first which does not
+func (o bObservable) TakeWhile(pred func(interface{}) bool) Observable {
+	return o.Lift(FunctionOperator(func(sub Subscriber, n Notification) {
+		if n.Type == OnNext && !pred(n.Body) {
+			sub.Notify(Complete())
+			return
+		}
+		sub.Notify(n)
+	}))
+}
+
+// emits values until the other observable emits a value, then completes
+func (o bObservable) TakeUntil(other Observable) Observable {
+	return o.Lift(FunctionOperator(func(sub Subscriber, n Notification) {
+		sub.Notify(n)
+		if n.Type == OnStart {
+			watch(other, sub, func(Notification) {
+				sub.Notify(Complete())
+			})
+		}
+	}))
+}
+
+// subscribes to the notifier for as long as sub is subscribed, calling onNext for its first value.
+// An error from the notifier is passed on to sub, terminating it
+func watch(notifier Observable, sub Subscriber, onNext func(Notification)) {
+	watching := notifier.Subscribe()
+	sub.Add(watching.Unsubscribe)
+	go func() {
+		defer watching.Unsubscribe()
+		for n := range watching.Events() {
+			switch n.Type {
+			case OnNext:
+				onNext(n)
+				return
+			case OnError:
+				sub.Notify(n)
+				sub.Notify(Complete())
+				return
+			}
+		}
+	}()
+}
+
+// emits only the last n values, once the source completes
+func (o bObservable) TakeLast(n int) Observable {
+	return o.LiftFunc(func() Operator {
+		var last []interface{}
+		return FunctionOperator(func(sub Subscriber, not Notification) {
+			switch not.Type {
+			case OnNext:
+				if n <= 0 {
+					return
+				}
+				if len(last) == n {
+					last = last[1:]
+				}
+				last = append(last, not.Body)
+				return
+			case OnComplete:
+				for _, v := range last {
+					sub.Notify(Next(v))
+				}
+			}
+			sub.Notify(not)
+		})
+	})
+}
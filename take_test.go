@@ -0,0 +1,45 @@
+package urx
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTakeUnsubscribes(t *testing.T) {
+	stopped := make(chan interface{})
+	obs := CreateContext(func(ctx context.Context, sub Subscriber) {
+		defer close(stopped)
+		for i := 0; ; i++ {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Millisecond):
+				sub.Notify(Next(i))
+			}
+		}
+	}).Map(func(in interface{}) interface{} {
+		return in.(int) * 2
+	}).Take(3)
+
+	values, err := collect(obs)
+	if err != nil || !reflect.DeepEqual(values, []interface{}{0, 2, 4}) {
+		t.Errorf("got %v, %v", values, err)
+	}
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Error("source still running after take finished")
+	}
+}
+
+func TestTakePerSubscription(t *testing.T) {
+	obs := Range(0, 5).Take(2)
+	for i := 0; i < 2; i++ {
+		values, _ := collect(obs)
+		if !reflect.DeepEqual(values, []interface{}{0, 1}) {
+			t.Errorf("subscription %d got %v", i, values)
+		}
+	}
+}
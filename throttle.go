@@ -0,0 +1,202 @@
+package urx
+
+import "time"
+
+// subscribes to obs for as long as sub is subscribed
+func subscribeFor(obs Observable, sub Subscriber) Subscription {
+	inner := obs.Subscribe()
+	sub.Add(inner.Unsubscribe)
+	return inner
+}
+
+// a timer which can be restarted, whose channel is nil while it is not running
+type restartableTimer struct {
+	clock Clock
+	timer ClockTimer
+}
+
+func (t *restartableTimer) start(d time.Duration) {
+	t.stop()
+	t.timer = t.clock.NewTimer(d)
+}
+
+func (t *restartableTimer) stop() {
+	if t.timer != nil {
+		t.timer.Stop()
+		t.timer = nil
+	}
+}
+
+// the timer's channel, which must be followed by a call to fired once it is received from
+func (t *restartableTimer) C() <-chan time.Time {
+	if t.timer == nil {
+		return nil
+	}
+	return t.timer.C()
+}
+
+func (t *restartableTimer) fired() {
+	t.timer = nil
+}
+
+// the latest value which has not been emitted yet
+type pendingValue struct {
+	value interface{}
+	ok    bool
+}
+
+func (p *pendingValue) set(v interface{}) {
+	p.value, p.ok = v, true
+}
+
+// emits the pending value, if there is one
+func (p *pendingValue) flush(sub Subscriber) {
+	if p.ok {
+		sub.Notify(Next(p.value))
+		p.value, p.ok = nil, false
+	}
+}
+
+// emits a value only once d has passed without another value arriving.
+// The pending value is emitted when the source completes, and dropped if it errors
+func (o bObservable) Debounce(d time.Duration, clock ...Clock) Observable {
+	c := clockOf(clock)
+	return Create(func(sub Subscriber) {
+		inner := subscribeFor(o, sub)
+		timer := &restartableTimer{clock: c}
+		defer timer.stop()
+		var pending pendingValue
+		for {
+			select {
+			case n, ok := <-inner.Events():
+				switch {
+				case !ok:
+					return
+				case n.Type == OnNext:
+					pending.set(n.Body)
+					timer.start(d)
+				case n.Type == OnComplete:
+					pending.flush(sub)
+					sub.Notify(n)
+					return
+				case n.Type == OnError:
+					sub.Notify(n)
+					return
+				}
+			case <-timer.C():
+				timer.fired()
+				pending.flush(sub)
+			}
+		}
+	})
+}
+
+// emits a value and then ignores any others until d has passed
+func (o bObservable) ThrottleFirst(d time.Duration, clock ...Clock) Observable {
+	c := clockOf(clock)
+	return Create(func(sub Subscriber) {
+		inner := subscribeFor(o, sub)
+		timer := &restartableTimer{clock: c}
+		defer timer.stop()
+		for {
+			select {
+			case n, ok := <-inner.Events():
+				switch {
+				case !ok:
+					return
+				case n.Type == OnNext:
+					if timer.C() == nil {
+						sub.Notify(n)
+						timer.start(d)
+					}
+				case n.Type == OnComplete || n.Type == OnError:
+					sub.Notify(n)
+					return
+				}
+			case <-timer.C():
+				timer.fired()
+			}
+		}
+	})
+}
+
+// when a value arrives, waits for d and then emits the latest value.
+// The pending value is emitted when the source completes, and dropped if it errors
+func (o bObservable) Audit(d time.Duration, clock ...Clock) Observable {
+	c := clockOf(clock)
+	return Create(func(sub Subscriber) {
+		inner := subscribeFor(o, sub)
+		timer := &restartableTimer{clock: c}
+		defer timer.stop()
+		var pending pendingValue
+		for {
+			select {
+			case n, ok := <-inner.Events():
+				switch {
+				case !ok:
+					return
+				case n.Type == OnNext:
+					pending.set(n.Body)
+					if timer.C() == nil {
+						timer.start(d)
+					}
+				case n.Type == OnComplete:
+					pending.flush(sub)
+					sub.Notify(n)
+					return
+				case n.Type == OnError:
+					sub.Notify(n)
+					return
+				}
+			case <-timer.C():
+				timer.fired()
+				pending.flush(sub)
+			}
+		}
+	})
+}
+
+// the same as Audit
+func (o bObservable) ThrottleLast(d time.Duration, clock ...Clock) Observable {
+	return o.Audit(d, clock...)
+}
+
+// emits the latest value, if there is a new one, every d. A value pending when the source completes is dropped.
+// Panics if d is not positive
+func (o bObservable) Sample(d time.Duration, clock ...Clock) Observable {
+	return o.SampleWith(Interval(d, clock...))
+}
+
+// emits the latest value, if there is a new one, whenever the notifier emits.
+// A value pending when the source completes is dropped, and an error from the notifier is passed on
+func (o bObservable) SampleWith(notifier Observable) Observable {
+	return Create(func(sub Subscriber) {
+		inner := subscribeFor(o, sub)
+		samples := subscribeFor(notifier, sub).Events()
+		var pending pendingValue
+		for {
+			select {
+			case n, ok := <-inner.Events():
+				switch {
+				case !ok:
+					return
+				case n.Type == OnNext:
+					pending.set(n.Body)
+				case n.Type == OnComplete || n.Type == OnError:
+					sub.Notify(n)
+					return
+				}
+			case n, ok := <-samples:
+				switch {
+				case !ok || n.Type == OnComplete:
+					samples = nil
+				case n.Type == OnNext:
+					pending.flush(sub)
+				case n.Type == OnError:
+					sub.Notify(n)
+					return
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,56 @@
+package urx
+
+import (
+	"context"
+	"time"
+)
+
+// emits 0, 1, 2... with period between each value, starting one period after subscription.
+// Panics if period is not positive
+func Interval(period time.Duration, clock ...Clock) Observable {
+	return TimerPeriodic(period, period, clock...)
+}
+
+// emits 0 after the delay and then completes
+func Timer(delay time.Duration, clock ...Clock) Observable {
+	c := clockOf(clock)
+	return CreateContext(func(ctx context.Context, sub Subscriber) {
+		timer := c.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C():
+			sub.Notify(Next(0))
+			sub.Notify(Complete())
+		case <-ctx.Done():
+		}
+	})
+}
+
+// emits 0 after the delay and then 1, 2, 3... with period between each value.
+// The underlying ticker is stopped as soon as the subscriber is done. Panics if period is not positive
+func TimerPeriodic(delay, period time.Duration, clock ...Clock) Observable {
+	if period <= 0 {
+		panic("urx: TimerPeriodic needs a positive period")
+	}
+	c := clockOf(clock)
+	return CreateContext(func(ctx context.Context, sub Subscriber) {
+		timer := c.NewTimer(delay)
+		select {
+		case <-timer.C():
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		}
+		ticker := c.NewTicker(period)
+		defer ticker.Stop()
+		sub.Notify(Next(0))
+		for i := 1; ; i++ {
+			select {
+			case <-ticker.C():
+				sub.Notify(Next(i))
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+}
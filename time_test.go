@@ -0,0 +1,42 @@
+package urx
+
+import (
+	"testing"
+	"time"
+)
+
+func TestInterval(t *testing.T) {
+	sub := Interval(time.Millisecond * 5).Subscribe()
+	for i := 0; i < 3; i++ {
+		if v := <-sub.Values(); v != i {
+			t.Errorf("expected %d but got %v", i, v)
+		}
+	}
+	sub.Unsubscribe()
+	if sub.IsSubscribed() {
+		t.Error("interval is still subscribed")
+	}
+}
+
+func TestTimer(t *testing.T) {
+	start := time.Now()
+	if err := Timer(time.Millisecond * 20).Wait(); err != nil {
+		t.Error(err)
+	}
+	if time.Since(start) < time.Millisecond*20 {
+		t.Error("timer completed before its delay")
+	}
+}
+
+func TestTimerPeriodicPeriod(t *testing.T) {
+	for _, period := range []time.Duration{0, -time.Second} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Interval accepted a period of %v", period)
+				}
+			}()
+			Interval(period)
+		}()
+	}
+}
@@ -0,0 +1,51 @@
+package typed
+
+// the types which Sum and Average work on
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// emits the accumulator after each value, starting from seed
+func Scan[T, A any](obs Observable[T], seed A, acc func(A, T) A) Observable[A] {
+	return wrap[A](obs.Untyped().Scan(seed, func(state, v interface{}) interface{} {
+		return acc(as[A](state), as[T](v))
+	}))
+}
+
+// emits the final accumulator, starting from seed, once the source completes
+func Reduce[T, A any](obs Observable[T], seed A, acc func(A, T) A) Observable[A] {
+	return wrap[A](obs.Untyped().Reduce(seed, func(state, v interface{}) interface{} {
+		return acc(as[A](state), as[T](v))
+	}))
+}
+
+// emits the total of the values once the source completes
+func Sum[T Number](obs Observable[T]) Observable[T] {
+	var zero T
+	return Reduce(obs, zero, func(sum, v T) T {
+		return sum + v
+	})
+}
+
+// emits the mean of the values once the source completes, or nothing if there were no values
+func Average[T Number](obs Observable[T]) Observable[float64] {
+	return wrap[float64](obs.Untyped().Average())
+}
+
+func (o tObservable[T]) Count() Observable[int] {
+	return wrap[int](o.obs.Count())
+}
+
+func (o tObservable[T]) Min(less func(a, b T) bool) Observable[T] {
+	return wrap[T](o.obs.Min(func(a, b interface{}) bool {
+		return less(as[T](a), as[T](b))
+	}))
+}
+
+func (o tObservable[T]) Max(less func(a, b T) bool) Observable[T] {
+	return wrap[T](o.obs.Max(func(a, b interface{}) bool {
+		return less(as[T](a), as[T](b))
+	}))
+}
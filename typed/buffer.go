@@ -0,0 +1,39 @@
+package typed
+
+import (
+	"time"
+
+	"github.com/Spectonic/urx"
+)
+
+// emits the values in batches of size, starting a new batch every skip values (or every size values if
+// skip is 0 or less). Any partial batches are emitted when the source completes
+func BufferCount[T any](obs Observable[T], size, skip int) Observable[[]T] {
+	return batches[T](obs.Untyped().BufferCount(size, skip))
+}
+
+// emits the values which arrived in each period of d as a batch, skipping empty batches
+func BufferTime[T any](obs Observable[T], d time.Duration, clock ...urx.Clock) Observable[[]T] {
+	return batches[T](obs.Untyped().BufferTime(d, clock...))
+}
+
+// like BufferTime, but a batch is also emitted as soon as it has max values
+func BufferTimeOrCount[T any](obs Observable[T], d time.Duration, max int, clock ...urx.Clock) Observable[[]T] {
+	return batches[T](obs.Untyped().BufferTimeOrCount(d, max, clock...))
+}
+
+// emits the values which arrived before each value from closing as a batch, skipping empty batches
+func BufferWhen[T any](obs Observable[T], closing urx.Observable) Observable[[]T] {
+	return batches[T](obs.Untyped().BufferWhen(closing))
+}
+
+func batches[T any](obs urx.Observable) Observable[[]T] {
+	return wrap[[]T](obs.Map(func(in interface{}) interface{} {
+		untyped := in.([]interface{})
+		out := make([]T, len(untyped))
+		for i := range untyped {
+			out[i] = as[T](untyped[i])
+		}
+		return out
+	}))
+}
@@ -0,0 +1,36 @@
+package typed
+
+import "github.com/Spectonic/urx"
+
+// emits the nth value from each observable, completing once any observable completes
+// and none of its values are left waiting
+func Zip[T any](obs ...Observable[T]) Observable[[]T] {
+	return batches[T](urx.Zip(untypedAll(obs)...))
+}
+
+// once every observable has emitted, emits the combination of the latest value from
+// each whenever any of them emits
+func CombineLatest[T, R any](combiner func([]T) R, obs ...Observable[T]) Observable[R] {
+	return wrap[R](urx.CombineLatest(func(values []interface{}) interface{} {
+		typed := make([]T, len(values))
+		for i := range values {
+			typed[i] = as[T](values[i])
+		}
+		return combiner(typed)
+	}, untypedAll(obs)...))
+}
+
+// emits the combination of each value and the latest value from other, once other has emitted
+func WithLatestFrom[T, U, R any](obs Observable[T], other Observable[U], combiner func(T, U) R) Observable[R] {
+	return Map(wrap[[]interface{}](obs.Untyped().WithLatestFrom(other.Untyped())), func(pair []interface{}) R {
+		return combiner(as[T](pair[0]), as[U](pair[1]))
+	})
+}
+
+func untypedAll[T any](obs []Observable[T]) []urx.Observable {
+	untyped := make([]urx.Observable, len(obs))
+	for i := range obs {
+		untyped[i] = obs[i].Untyped()
+	}
+	return untyped
+}
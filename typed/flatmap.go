@@ -0,0 +1,31 @@
+package typed
+
+import "github.com/Spectonic/urx"
+
+// maps each value to an inner observable and merges their values, subscribing to at most
+// maxConcurrent inners at once if it is given
+func FlatMap[T, U any](obs Observable[T], project func(T) Observable[U], maxConcurrent ...int) Observable[U] {
+	return wrap[U](obs.Untyped().FlatMap(untypedProject(project), maxConcurrent...))
+}
+
+// maps each value to an inner observable and emits their values one inner at a time, in order
+func ConcatMap[T, U any](obs Observable[T], project func(T) Observable[U]) Observable[U] {
+	return wrap[U](obs.Untyped().ConcatMap(untypedProject(project)))
+}
+
+// maps each value to an inner observable and emits the values of the latest one
+func SwitchMap[T, U any](obs Observable[T], project func(T) Observable[U]) Observable[U] {
+	return wrap[U](obs.Untyped().SwitchMap(untypedProject(project)))
+}
+
+// maps each value to an inner observable and emits its values, ignoring values which arrive
+// while an inner is still active
+func ExhaustMap[T, U any](obs Observable[T], project func(T) Observable[U]) Observable[U] {
+	return wrap[U](obs.Untyped().ExhaustMap(untypedProject(project)))
+}
+
+func untypedProject[T, U any](project func(T) Observable[U]) func(interface{}) urx.Observable {
+	return func(in interface{}) urx.Observable {
+		return project(as[T](in)).Untyped()
+	}
+}
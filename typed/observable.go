@@ -0,0 +1,337 @@
+// Package typed is a type-safe layer over urx. Every typed observable wraps an
+// untyped urx.Observable, so the two can be converted freely with From and Untyped.
+package typed
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"reflect"
+	"time"
+
+	"github.com/Spectonic/urx"
+)
+
+type Observable[T any] interface {
+	Publish() PublishedObservable[T]
+	Share() Observable[T]
+	Lift(urx.Operator) Observable[T]
+	LiftFunc(urx.OperatorFactory) Observable[T]
+	Filter(func(T) bool) Observable[T]
+	Buffered(buffer int) Observable[T]
+	Take(n int) Observable[T]
+	TakeWhile(func(T) bool) Observable[T]
+	TakeUntil(other urx.Observable) Observable[T]
+	TakeLast(n int) Observable[T]
+	Skip(n int) Observable[T]
+	SkipWhile(func(T) bool) Observable[T]
+	SkipUntil(other urx.Observable) Observable[T]
+	SkipLast(n int) Observable[T]
+	Count() Observable[int]
+	Min(less func(a, b T) bool) Observable[T]
+	Max(less func(a, b T) bool) Observable[T]
+	DistinctUntilChanged(equal func(a, b T) bool) Observable[T]
+	DistinctUntilKeyChanged(key func(T) any) Observable[T]
+	Distinct(key func(T) any, opts ...urx.DistinctOptions) Observable[T]
+	Debounce(d time.Duration, clock ...urx.Clock) Observable[T]
+	ThrottleFirst(d time.Duration, clock ...urx.Clock) Observable[T]
+	ThrottleLast(d time.Duration, clock ...urx.Clock) Observable[T]
+	Audit(d time.Duration, clock ...urx.Clock) Observable[T]
+	Sample(d time.Duration, clock ...urx.Clock) Observable[T]
+	SampleWith(notifier urx.Observable) Observable[T]
+	Subscribe() Subscription[T]
+	SubscribeContext(context.Context) Subscription[T]
+	SubscribeWith(urx.Observer) Subscription[T]
+	SubscribeFunc(onNext func(T), onError func(error), onComplete func()) Subscription[T]
+	ForEach(func(T)) error
+	Wait() error
+	ObserveOn(urx.Scheduler) Observable[T]
+	SubscribeOn(urx.Scheduler) Observable[T]
+	ToSeq(context.Context) iter.Seq[T]
+	ToSeqErr(context.Context) iter.Seq2[T, error]
+	All() iter.Seq[T]
+	Untyped() urx.Observable
+}
+
+type PublishedObservable[T any] interface {
+	Observable[T]
+	Unsubscribe()
+	IsSubscribed() bool
+	Add(urx.CompleteHook)
+}
+
+// From converts an untyped observable into a typed one. Values which are not a T
+// terminate the stream with an error instead of panicking in the consumer. A nil value
+// is only a T if T can be nil.
+func From[T any](obs urx.Observable) Observable[T] {
+	nilable := canBeNil[T]()
+	return wrap[T](obs.Lift(urx.FunctionOperator(func(sub urx.Subscriber, n urx.Notification) {
+		if n.Type == urx.OnNext && (n.Body != nil || !nilable) {
+			if _, ok := n.Body.(T); !ok {
+				sub.Notify(urx.Error(fmt.Errorf("urx/typed: expected %s but got %T", typeName[T](), n.Body)))
+				sub.Notify(urx.Complete())
+				return
+			}
+		}
+		sub.Notify(n)
+	})))
+}
+
+// creates an observable from a function, which is called on the scheduler (a new goroutine by default)
+func Create[T any](onSub func(Subscriber[T]), scheduler ...urx.Scheduler) Observable[T] {
+	return wrap[T](urx.Create(func(sub urx.Subscriber) {
+		onSub(tSubscriber[T]{sub})
+	}, scheduler...))
+}
+
+// creates an observable from a function whose context is cancelled once the subscriber is done
+func CreateContext[T any](onSub func(context.Context, Subscriber[T]), scheduler ...urx.Scheduler) Observable[T] {
+	return wrap[T](urx.CreateContext(func(ctx context.Context, sub urx.Subscriber) {
+		onSub(ctx, tSubscriber[T]{sub})
+	}, scheduler...))
+}
+
+func FromChan[T any](source <-chan T, scheduler ...urx.Scheduler) Observable[T] {
+	return wrap[T](urx.FromChan(source, scheduler...))
+}
+
+func Map[T, U any](obs Observable[T], m func(T) U) Observable[U] {
+	return wrap[U](obs.Untyped().Map(func(in interface{}) interface{} {
+		return m(as[T](in))
+	}))
+}
+
+func Merge[T any](obs ...Observable[T]) Observable[T] {
+	return wrap[T](urx.Merge(untypedAll(obs)...))
+}
+
+type tObservable[T any] struct {
+	obs urx.Observable
+}
+
+// wraps an observable which is already known to only produce values of type T
+func wrap[T any](obs urx.Observable) Observable[T] {
+	return tObservable[T]{obs}
+}
+
+func (o tObservable[T]) Publish() PublishedObservable[T] {
+	p := o.obs.Publish()
+	return pObservable[T]{tObservable[T]{p}, p}
+}
+
+func (o tObservable[T]) Share() Observable[T] {
+	return wrap[T](o.obs.Share())
+}
+
+func (o tObservable[T]) Lift(op urx.Operator) Observable[T] {
+	return From[T](o.obs.Lift(op))
+}
+
+func (o tObservable[T]) LiftFunc(newOp urx.OperatorFactory) Observable[T] {
+	return From[T](o.obs.LiftFunc(newOp))
+}
+
+func (o tObservable[T]) Filter(f func(T) bool) Observable[T] {
+	return wrap[T](o.obs.Filter(func(in interface{}) bool {
+		return f(as[T](in))
+	}))
+}
+
+func (o tObservable[T]) Buffered(buffer int) Observable[T] {
+	return wrap[T](o.obs.Buffered(buffer))
+}
+
+func (o tObservable[T]) Take(n int) Observable[T] {
+	return wrap[T](o.obs.Take(n))
+}
+
+func (o tObservable[T]) TakeWhile(pred func(T) bool) Observable[T] {
+	return wrap[T](o.obs.TakeWhile(func(in interface{}) bool {
+		return pred(as[T](in))
+	}))
+}
+
+func (o tObservable[T]) TakeUntil(other urx.Observable) Observable[T] {
+	return wrap[T](o.obs.TakeUntil(other))
+}
+
+func (o tObservable[T]) TakeLast(n int) Observable[T] {
+	return wrap[T](o.obs.TakeLast(n))
+}
+
+func (o tObservable[T]) Skip(n int) Observable[T] {
+	return wrap[T](o.obs.Skip(n))
+}
+
+func (o tObservable[T]) SkipWhile(pred func(T) bool) Observable[T] {
+	return wrap[T](o.obs.SkipWhile(func(in interface{}) bool {
+		return pred(as[T](in))
+	}))
+}
+
+func (o tObservable[T]) SkipUntil(other urx.Observable) Observable[T] {
+	return wrap[T](o.obs.SkipUntil(other))
+}
+
+func (o tObservable[T]) SkipLast(n int) Observable[T] {
+	return wrap[T](o.obs.SkipLast(n))
+}
+
+func (o tObservable[T]) DistinctUntilChanged(equal func(a, b T) bool) Observable[T] {
+	if equal == nil {
+		return wrap[T](o.obs.DistinctUntilChanged(nil))
+	}
+	return wrap[T](o.obs.DistinctUntilChanged(func(a, b interface{}) bool {
+		return equal(as[T](a), as[T](b))
+	}))
+}
+
+func (o tObservable[T]) DistinctUntilKeyChanged(key func(T) any) Observable[T] {
+	return wrap[T](o.obs.DistinctUntilKeyChanged(func(in interface{}) interface{} {
+		return key(as[T](in))
+	}))
+}
+
+func (o tObservable[T]) Distinct(key func(T) any, opts ...urx.DistinctOptions) Observable[T] {
+	if key == nil {
+		return wrap[T](o.obs.Distinct(nil, opts...))
+	}
+	return wrap[T](o.obs.Distinct(func(in interface{}) interface{} {
+		return key(as[T](in))
+	}, opts...))
+}
+
+func (o tObservable[T]) Debounce(d time.Duration, clock ...urx.Clock) Observable[T] {
+	return wrap[T](o.obs.Debounce(d, clock...))
+}
+
+func (o tObservable[T]) ThrottleFirst(d time.Duration, clock ...urx.Clock) Observable[T] {
+	return wrap[T](o.obs.ThrottleFirst(d, clock...))
+}
+
+func (o tObservable[T]) ThrottleLast(d time.Duration, clock ...urx.Clock) Observable[T] {
+	return wrap[T](o.obs.ThrottleLast(d, clock...))
+}
+
+func (o tObservable[T]) Audit(d time.Duration, clock ...urx.Clock) Observable[T] {
+	return wrap[T](o.obs.Audit(d, clock...))
+}
+
+func (o tObservable[T]) Sample(d time.Duration, clock ...urx.Clock) Observable[T] {
+	return wrap[T](o.obs.Sample(d, clock...))
+}
+
+func (o tObservable[T]) SampleWith(notifier urx.Observable) Observable[T] {
+	return wrap[T](o.obs.SampleWith(notifier))
+}
+
+func (o tObservable[T]) Subscribe() Subscription[T] {
+	return &tSubscription[T]{Subscription: o.obs.Subscribe()}
+}
+
+func (o tObservable[T]) SubscribeContext(ctx context.Context) Subscription[T] {
+	return &tSubscription[T]{Subscription: o.obs.SubscribeContext(ctx)}
+}
+
+func (o tObservable[T]) SubscribeWith(observer urx.Observer) Subscription[T] {
+	return &tSubscription[T]{Subscription: o.obs.SubscribeWith(observer)}
+}
+
+func (o tObservable[T]) SubscribeFunc(onNext func(T), onError func(error), onComplete func()) Subscription[T] {
+	var next func(interface{})
+	if onNext != nil {
+		next = func(in interface{}) {
+			onNext(as[T](in))
+		}
+	}
+	return &tSubscription[T]{Subscription: o.obs.SubscribeFunc(next, onError, onComplete)}
+}
+
+func (o tObservable[T]) ForEach(f func(T)) error {
+	return o.obs.ForEach(func(in interface{}) {
+		if f != nil {
+			f(as[T](in))
+		}
+	})
+}
+
+func (o tObservable[T]) Wait() error {
+	return o.obs.Wait()
+}
+
+func (o tObservable[T]) ObserveOn(s urx.Scheduler) Observable[T] {
+	return wrap[T](o.obs.ObserveOn(s))
+}
+
+func (o tObservable[T]) SubscribeOn(s urx.Scheduler) Observable[T] {
+	return wrap[T](o.obs.SubscribeOn(s))
+}
+
+func (o tObservable[T]) ToSeq(ctx context.Context) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range o.obs.ToSeq(ctx) {
+			if !yield(as[T](v)) {
+				return
+			}
+		}
+	}
+}
+
+func (o tObservable[T]) ToSeqErr(ctx context.Context) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for v, err := range o.obs.ToSeqErr(ctx) {
+			if !yield(as[T](v), err) {
+				return
+			}
+		}
+	}
+}
+
+func (o tObservable[T]) All() iter.Seq[T] {
+	return o.ToSeq(context.Background())
+}
+
+func (o tObservable[T]) Untyped() urx.Observable {
+	return o.obs
+}
+
+type pObservable[T any] struct {
+	tObservable[T]
+	p urx.PublishedObservable
+}
+
+func (p pObservable[T]) Publish() PublishedObservable[T] {
+	return p
+}
+
+func (p pObservable[T]) Unsubscribe() {
+	p.p.Unsubscribe()
+}
+
+func (p pObservable[T]) IsSubscribed() bool {
+	return p.p.IsSubscribed()
+}
+
+func (p pObservable[T]) Add(h urx.CompleteHook) {
+	p.p.Add(h)
+}
+
+// as converts a value already known to be a T (or nil) into a T
+func as[T any](in interface{}) (out T) {
+	if in != nil {
+		out = in.(T)
+	}
+	return
+}
+
+func typeName[T any]() string {
+	return reflect.TypeOf((*T)(nil)).Elem().String()
+}
+
+func canBeNil[T any]() bool {
+	switch reflect.TypeOf((*T)(nil)).Elem().Kind() {
+	case reflect.Interface, reflect.Pointer, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return true
+	}
+	return false
+}
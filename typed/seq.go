@@ -0,0 +1,29 @@
+package typed
+
+import (
+	"iter"
+
+	"github.com/Spectonic/urx"
+)
+
+// the values emitted by FromSeq2
+type KeyValue[K, V any] struct {
+	Key   K
+	Value V
+}
+
+// emits every value of the sequence and then completes
+func FromSeq[T any](seq iter.Seq[T]) Observable[T] {
+	return wrap[T](urx.FromSeq(seq))
+}
+
+// emits a KeyValue for every pair in the sequence and then completes
+func FromSeq2[K, V any](seq iter.Seq2[K, V]) Observable[KeyValue[K, V]] {
+	return FromSeq(func(yield func(KeyValue[K, V]) bool) {
+		for k, v := range seq {
+			if !yield(KeyValue[K, V]{k, v}) {
+				return
+			}
+		}
+	})
+}
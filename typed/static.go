@@ -0,0 +1,44 @@
+package typed
+
+import "github.com/Spectonic/urx"
+
+// emits each of the values and then completes
+func Just[T any](values ...T) Observable[T] {
+	return FromSlice(values)
+}
+
+// emits each value in the slice and then completes
+func FromSlice[T any](values []T) Observable[T] {
+	untyped := make([]interface{}, len(values))
+	for i := range values {
+		untyped[i] = values[i]
+	}
+	return wrap[T](urx.FromSlice(untyped))
+}
+
+// emits count ints counting up from start and then completes
+func Range(start, count int) Observable[int] {
+	return wrap[int](urx.Range(start, count))
+}
+
+// completes without emitting anything
+func Empty[T any]() Observable[T] {
+	return wrap[T](urx.Empty())
+}
+
+// never emits anything, including completion
+func Never[T any]() Observable[T] {
+	return wrap[T](urx.Never())
+}
+
+// emits the error without emitting any values
+func Throw[T any](err error) Observable[T] {
+	return wrap[T](urx.Throw(err))
+}
+
+// calls f to create a new observable for every subscription
+func Defer[T any](f func() Observable[T]) Observable[T] {
+	return wrap[T](urx.Defer(func() urx.Observable {
+		return f().Untyped()
+	}))
+}
@@ -0,0 +1,74 @@
+package typed
+
+import (
+	"context"
+
+	"github.com/Spectonic/urx"
+)
+
+type Subject[T any] interface {
+	Next(T)
+	Error(error)
+	Complete()
+	Post(urx.Notification) error
+	TryPost(urx.Notification) bool
+	PostContext(context.Context, urx.Notification) error
+	Subscribe() Subscription[T]
+	AsObservable() Observable[T]
+	Untyped() urx.Subject
+}
+
+type tSubject[T any] struct {
+	urx.Subject
+}
+
+func NewPublishSubject[T any](opts ...urx.SubjectOptions) Subject[T] {
+	return tSubject[T]{urx.NewPublishSubject(opts...)}
+}
+
+func (s tSubject[T]) Next(value T) {
+	s.Subject.Next(value)
+}
+
+func (s tSubject[T]) Subscribe() Subscription[T] {
+	return s.AsObservable().Subscribe()
+}
+
+func (s tSubject[T]) AsObservable() Observable[T] {
+	return From[T](s.Subject.AsObservable())
+}
+
+func (s tSubject[T]) Untyped() urx.Subject {
+	return s.Subject
+}
+
+type BehaviorSubject[T any] interface {
+	Subject[T]
+	// the most recent value passed to Next, or the initial value
+	Value() T
+}
+
+type tBehaviorSubject[T any] struct {
+	tSubject[T]
+	b urx.BehaviorSubject
+}
+
+// creates a subject which emits its latest value (starting with initial) to every new subscriber
+func NewBehaviorSubject[T any](initial T, opts ...urx.SubjectOptions) BehaviorSubject[T] {
+	b := urx.NewBehaviorSubject(initial, opts...)
+	return tBehaviorSubject[T]{tSubject[T]{b}, b}
+}
+
+func (s tBehaviorSubject[T]) Value() T {
+	return as[T](s.b.Value())
+}
+
+// creates a subject which replays the values it has emitted (limited by the options) to every new subscriber
+func NewReplaySubject[T any](opts urx.ReplayOptions) Subject[T] {
+	return tSubject[T]{urx.NewReplaySubject(opts)}
+}
+
+// creates a subject which only emits the last value passed to Next once it completes
+func NewAsyncSubject[T any](opts ...urx.SubjectOptions) Subject[T] {
+	return tSubject[T]{urx.NewAsyncSubject(opts...)}
+}
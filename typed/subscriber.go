@@ -0,0 +1,83 @@
+package typed
+
+import (
+	"sync"
+
+	"github.com/Spectonic/urx"
+)
+
+type Subscriber[T any] interface {
+	Next(T)
+	Error(error)
+	Complete()
+	urx.Subscriber
+}
+
+type tSubscriber[T any] struct {
+	urx.Subscriber
+}
+
+func (s tSubscriber[T]) Next(value T) {
+	s.Notify(urx.Next(value))
+}
+
+func (s tSubscriber[T]) Error(err error) {
+	s.Notify(urx.Error(err))
+}
+
+func (s tSubscriber[T]) Complete() {
+	s.Notify(urx.Complete())
+}
+
+type Subscription[T any] interface {
+	Events() <-chan urx.Notification
+	Unsubscribe()
+	Values() <-chan T
+	Error() <-chan error
+	Complete() <-chan interface{}
+	urx.RootSubscriber
+}
+
+type tSubscription[T any] struct {
+	urx.Subscription
+	values chan T
+	once   sync.Once
+	//closed by Unsubscribe, so converted values are dropped once nothing will read them
+	done      chan interface{}
+	doneOnce  sync.Once
+	unsubOnce sync.Once
+}
+
+func (s *tSubscription[T]) Values() <-chan T {
+	s.once.Do(func() {
+		s.values = make(chan T)
+		in := s.Subscription.Values()
+		done := s.unsubscribed()
+		go func() {
+			defer close(s.values)
+			for v := range in {
+				//once unsubscribed the values are dropped, but in is still drained so the
+				//untyped subscription can finish
+				select {
+				case s.values <- as[T](v):
+				case <-done:
+				}
+			}
+		}()
+	})
+	return s.values
+}
+
+func (s *tSubscription[T]) Unsubscribe() {
+	s.unsubOnce.Do(func() {
+		close(s.unsubscribed())
+	})
+	s.Subscription.Unsubscribe()
+}
+
+func (s *tSubscription[T]) unsubscribed() chan interface{} {
+	s.doneOnce.Do(func() {
+		s.done = make(chan interface{})
+	})
+	return s.done
+}
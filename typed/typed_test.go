@@ -0,0 +1,190 @@
+package typed
+
+import (
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/Spectonic/urx"
+)
+
+func createChanObs(to int, rate time.Duration) Observable[int] {
+	inChan := make(chan int)
+	go func() {
+		for i := 0; i < to; i++ {
+			<-time.After(rate)
+			inChan <- i
+		}
+		close(inChan)
+	}()
+	return FromChan(inChan)
+}
+
+func TestMapFilter(t *testing.T) {
+	obs := Map(createChanObs(10, time.Millisecond*5).Filter(func(in int) bool {
+		return in%2 == 0
+	}), strconv.Itoa)
+
+	var got []string
+	for v := range obs.Subscribe().Values() {
+		got = append(got, v)
+	}
+	if len(got) != 5 || got[0] != "0" || got[4] != "8" {
+		t.Errorf("unexpected values %v", got)
+	}
+}
+
+func TestFromWrongType(t *testing.T) {
+	c := make(chan interface{})
+	go func() {
+		c <- 1
+		c <- "two"
+		c <- 3
+		close(c)
+	}()
+
+	sub := From[int](urx.FromChan(c)).Subscribe()
+	var values []int
+	var errs []error
+	for e := range sub.Events() {
+		switch e.Type {
+		case urx.OnNext:
+			values = append(values, e.Body.(int))
+		case urx.OnError:
+			errs = append(errs, e.Error())
+		}
+	}
+	if len(values) != 1 || len(errs) != 1 {
+		t.Errorf("expected one value and one error, got %v and %v", values, errs)
+	}
+}
+
+func TestFromNil(t *testing.T) {
+	var ints []int
+	if err := From[int](urx.Just(nil)).ForEach(func(v int) {
+		ints = append(ints, v)
+	}); err == nil || len(ints) != 0 {
+		t.Errorf("expected only an error for a nil int, got %v and %v", ints, err)
+	}
+	var pointers []*int
+	if err := From[*int](urx.Just(nil)).ForEach(func(v *int) {
+		pointers = append(pointers, v)
+	}); err != nil || len(pointers) != 1 || pointers[0] != nil {
+		t.Errorf("expected a nil pointer, got %v and %v", pointers, err)
+	}
+}
+
+func TestCreateMerge(t *testing.T) {
+	count := func(from int) Observable[int] {
+		return Create(func(sub Subscriber[int]) {
+			for i := from; i < from+5; i++ {
+				sub.Next(i)
+			}
+			sub.Complete()
+		})
+	}
+
+	sum := 0
+	for v := range Merge(count(0), count(100)).Subscribe().Values() {
+		sum += v
+	}
+	if sum != 10+510 {
+		t.Errorf("expected 520 but got %d", sum)
+	}
+}
+
+func TestTypedSubject(t *testing.T) {
+	subj := NewPublishSubject[string]()
+	values := subj.Subscribe().Values()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	var got []string
+	go func() {
+		defer wg.Done()
+		for v := range values {
+			got = append(got, v)
+		}
+	}()
+
+	subj.Next("a")
+	subj.Next("b")
+	subj.Complete()
+	wg.Wait()
+	if len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Errorf("unexpected values %v", got)
+	}
+}
+
+func TestJust(t *testing.T) {
+	var got []string
+	err := Just("a", "b").ForEach(func(v string) {
+		got = append(got, v)
+	})
+	if err != nil || len(got) != 2 || got[1] != "b" {
+		t.Errorf("unexpected values %v and error %v", got, err)
+	}
+}
+
+func TestSeq(t *testing.T) {
+	got := slices.Collect(FromSeq(slices.Values([]int{1, 2, 3})).All())
+	if !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("unexpected values %v", got)
+	}
+}
+
+func TestAggregates(t *testing.T) {
+	source := Just(3, 1, 4)
+	running := slices.Collect(Scan(source, "", func(acc string, v int) string {
+		return acc + strconv.Itoa(v)
+	}).All())
+	if !slices.Equal(running, []string{"3", "31", "314"}) {
+		t.Errorf("unexpected scan %v", running)
+	}
+	sum := slices.Collect(Sum(source).All())
+	avg := slices.Collect(Average(source).All())
+	count := slices.Collect(source.Count().All())
+	max := slices.Collect(source.Max(func(a, b int) bool { return a < b }).All())
+	if !slices.Equal(sum, []int{8}) || len(avg) != 1 || avg[0] < 2.66 || avg[0] > 2.67 || !slices.Equal(count, []int{3}) || !slices.Equal(max, []int{4}) {
+		t.Errorf("unexpected aggregates %v %v %v %v", sum, avg, count, max)
+	}
+}
+
+func TestBufferCount(t *testing.T) {
+	var got [][]int
+	for batch := range BufferCount(Range(0, 5), 2, 0).All() {
+		got = append(got, batch)
+	}
+	if len(got) != 3 || !slices.Equal(got[0], []int{0, 1}) || !slices.Equal(got[2], []int{4}) {
+		t.Errorf("unexpected batches %v", got)
+	}
+}
+
+func TestZip(t *testing.T) {
+	got := slices.Collect(CombineLatest(func(values []int) int {
+		return values[0] + values[1]
+	}, Just(1), Just(10)).All())
+	zipped := slices.Collect(Zip(Just(1, 2, 3), Just(4, 5)).All())
+	if !slices.Equal(got, []int{11}) || len(zipped) != 2 || !slices.Equal(zipped[1], []int{2, 5}) {
+		t.Errorf("unexpected %v and %v", got, zipped)
+	}
+}
+
+func TestValuesUnsubscribe(t *testing.T) {
+	//synctest.Test fails if any goroutine is left behind
+	synctest.Test(t, func(t *testing.T) {
+		for i := 0; i < 10; i++ {
+			sub := Range(0, 100).Subscribe()
+			<-sub.Values()
+			sub.Unsubscribe()
+			sub.Unsubscribe()
+			if i%2 == 0 {
+				for range sub.Values() {
+				}
+			}
+		}
+	})
+}
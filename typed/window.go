@@ -0,0 +1,36 @@
+package typed
+
+import (
+	"time"
+
+	"github.com/Spectonic/urx"
+)
+
+// emits the values in windows of size
+func WindowCount[T any](obs Observable[T], size int) Observable[Observable[T]] {
+	return windows[T](obs.Untyped().WindowCount(size))
+}
+
+// emits the values which arrived in each period of d in a window
+func WindowTime[T any](obs Observable[T], d time.Duration, clock ...urx.Clock) Observable[Observable[T]] {
+	return windows[T](obs.Untyped().WindowTime(d, clock...))
+}
+
+// opens a window whenever openings emits, which gets values until the observable returned by closing
+// for that opening emits or completes
+func WindowToggle[T, O any](obs Observable[T], openings Observable[O], closing func(O) urx.Observable) Observable[Observable[T]] {
+	return windows[T](obs.Untyped().WindowToggle(openings.Untyped(), func(in interface{}) urx.Observable {
+		return closing(as[O](in))
+	}))
+}
+
+// emits the values which arrived before each value from closing in a window
+func WindowWhen[T any](obs Observable[T], closing urx.Observable) Observable[Observable[T]] {
+	return windows[T](obs.Untyped().WindowWhen(closing))
+}
+
+func windows[T any](obs urx.Observable) Observable[Observable[T]] {
+	return wrap[Observable[T]](obs.Map(func(in interface{}) interface{} {
+		return wrap[T](in.(urx.Observable))
+	}))
+}
@@ -0,0 +1,97 @@
+package urxtest
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Spectonic/urx"
+)
+
+// FrameDuration is the amount of virtual time in a frame
+const FrameDuration = time.Millisecond
+
+// Now returns the virtual time, which makes the Scheduler usable as a urx.Clock
+func (s *Scheduler) Now() time.Time {
+	return s.timeAt(s.Frame())
+}
+
+func (s *Scheduler) timeAt(frame int) time.Time {
+	return time.Unix(0, 0).Add(time.Duration(frame) * FrameDuration)
+}
+
+// the number of frames in d, rounded up
+func frames(d time.Duration) int {
+	return int((d + FrameDuration - 1) / FrameDuration)
+}
+
+func (s *Scheduler) NewTimer(d time.Duration) urx.ClockTimer {
+	t := &virtualTimer{c: make(chan time.Time, 1)}
+	frame := s.Frame() + frames(d)
+	s.schedule(frame, func() {
+		if t.fire() {
+			t.c <- s.timeAt(frame)
+		}
+	})
+	return t
+}
+
+func (s *Scheduler) NewTicker(d time.Duration) urx.Ticker {
+	period := frames(d)
+	if period < 1 {
+		period = 1
+	}
+	t := &virtualTicker{virtualTimer{c: make(chan time.Time, 1)}}
+	var tick func(frame int)
+	tick = func(frame int) {
+		s.schedule(frame, func() {
+			if t.stopped() {
+				return
+			}
+			//like a time.Ticker, ticks are dropped for slow receivers
+			select {
+			case t.c <- s.timeAt(frame):
+			default:
+			}
+			tick(frame + period)
+		})
+	}
+	tick(s.Frame() + period)
+	return t
+}
+
+type virtualTimer struct {
+	c    chan time.Time
+	m    sync.Mutex
+	done bool
+}
+
+func (t *virtualTimer) C() <-chan time.Time {
+	return t.c
+}
+
+// marks the timer as done, reporting whether it was still pending
+func (t *virtualTimer) fire() bool {
+	t.m.Lock()
+	defer t.m.Unlock()
+	pending := !t.done
+	t.done = true
+	return pending
+}
+
+func (t *virtualTimer) stopped() bool {
+	t.m.Lock()
+	defer t.m.Unlock()
+	return t.done
+}
+
+func (t *virtualTimer) Stop() bool {
+	return t.fire()
+}
+
+type virtualTicker struct {
+	virtualTimer
+}
+
+func (t *virtualTicker) Stop() {
+	t.fire()
+}
@@ -0,0 +1,108 @@
+package urxtest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Spectonic/urx"
+)
+
+// ErrMarble is the error produced by a # in a marble diagram unless the values map has an error under "#"
+var ErrMarble = errors.New("urxtest: marble error")
+
+// A Record is a notification at a virtual frame
+type Record struct {
+	Frame        int
+	Notification urx.Notification
+}
+
+func (r Record) String() string {
+	switch r.Notification.Type {
+	case urx.OnNext:
+		return fmt.Sprintf("%d:next(%v)", r.Frame, r.Notification.Body)
+	case urx.OnError:
+		return fmt.Sprintf("%d:error(%v)", r.Frame, r.Notification.Body)
+	default:
+		return fmt.Sprintf("%d:%s", r.Frame, r.Notification.Type)
+	}
+}
+
+// ParseMarbles turns a marble diagram into the records it describes, along with the frame
+// of the subscription point (^), which is 0 when there is none. Frames are relative to the
+// subscription point.
+//
+//	a     a value, looked up in values (or the string itself when it is missing)
+//	|     completion
+//	#     an error
+//	^     the subscription point of a hot observable
+//	(ab)  a group of notifications which all happen in the same frame
+//
+// - is an empty frame. Every character outside a group takes one frame, a group takes one
+// frame in total and spaces are ignored
+func ParseMarbles(marbles string, values map[string]interface{}) (records []Record, subscription int) {
+	frame, groupStart := 0, -1
+	var raw []Record
+	for _, c := range marbles {
+		var n urx.Notification
+		switch c {
+		case ' ':
+			continue
+		case '-':
+			frame++
+			continue
+		case '^':
+			subscription = frame
+			frame++
+			continue
+		case '(':
+			if groupStart >= 0 {
+				panic(fmt.Sprintf("urxtest: nested group in marbles %q", marbles))
+			}
+			groupStart = frame
+			continue
+		case ')':
+			if groupStart < 0 {
+				panic(fmt.Sprintf("urxtest: unopened group in marbles %q", marbles))
+			}
+			frame = groupStart + 1
+			groupStart = -1
+			continue
+		case '|':
+			n = urx.Complete()
+		case '#':
+			err, ok := values["#"].(error)
+			if !ok {
+				err = ErrMarble
+			}
+			n = urx.Error(err)
+		default:
+			key := string(c)
+			body, ok := values[key]
+			if !ok {
+				body = key
+			}
+			n = urx.Next(body)
+		}
+		raw = append(raw, Record{Frame: frame, Notification: n})
+		if groupStart < 0 {
+			frame++
+		}
+	}
+	if groupStart >= 0 {
+		panic(fmt.Sprintf("urxtest: unclosed group in marbles %q", marbles))
+	}
+	for _, r := range raw {
+		r.Frame -= subscription
+		records = append(records, r)
+	}
+	return
+}
+
+func formatRecords(records []Record) string {
+	parts := make([]string, len(records))
+	for i := range records {
+		parts[i] = records[i].String()
+	}
+	return "[" + strings.Join(parts, " ") + "]"
+}
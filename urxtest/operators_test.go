@@ -0,0 +1,404 @@
+package urxtest
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/Spectonic/urx"
+)
+
+func TestTake(t *testing.T) {
+	Run(t, func(t *testing.T, s *Scheduler) {
+		s.ExpectObservable(s.Cold("-a-b-c-d|", ints).Take(2)).ToBe("-a-(b|)", ints)
+		s.ExpectObservable(s.Cold("-a|", ints).Take(2)).ToBe("-a|", ints)
+		s.Flush()
+	})
+}
+
+func TestTakeWhile(t *testing.T) {
+	Run(t, func(t *testing.T, s *Scheduler) {
+		obs := s.Cold("-a-b-c-d|", ints).TakeWhile(func(in interface{}) bool {
+			return in.(int) < 3
+		})
+		s.ExpectObservable(obs).ToBe("-a-b-|", ints)
+		s.Flush()
+	})
+}
+
+func TestTakeUntil(t *testing.T) {
+	Run(t, func(t *testing.T, s *Scheduler) {
+		s.ExpectObservable(s.Cold("-a-b-c-d|", ints).TakeUntil(s.Cold("----x", nil))).ToBe("-a-b|", ints)
+		s.ExpectObservable(s.Cold("-a-b|", ints).TakeUntil(s.Cold("-|", nil))).ToBe("-a-b|", ints)
+		s.Flush()
+	})
+}
+
+func TestTakeLast(t *testing.T) {
+	Run(t, func(t *testing.T, s *Scheduler) {
+		s.ExpectObservable(s.Cold("-a-b-c-d|", ints).TakeLast(2)).ToBe("--------(cd|)", ints)
+		s.ExpectObservable(s.Cold("-a-#", ints).TakeLast(2)).ToBe("---#", ints)
+		s.Flush()
+	})
+}
+
+func TestSkip(t *testing.T) {
+	Run(t, func(t *testing.T, s *Scheduler) {
+		s.ExpectObservable(s.Cold("-a-b-c-d|", ints).Skip(2)).ToBe("-----c-d|", ints)
+		s.Flush()
+	})
+}
+
+func TestSkipWhile(t *testing.T) {
+	Run(t, func(t *testing.T, s *Scheduler) {
+		obs := s.Cold("-a-c-b-d|", ints).SkipWhile(func(in interface{}) bool {
+			return in.(int) < 3
+		})
+		s.ExpectObservable(obs).ToBe("---c-b-d|", ints)
+		s.Flush()
+	})
+}
+
+func TestSkipUntil(t *testing.T) {
+	Run(t, func(t *testing.T, s *Scheduler) {
+		s.ExpectObservable(s.Cold("-a-b-c-d|", ints).SkipUntil(s.Cold("--x", nil))).ToBe("---b-c-d|", ints)
+		s.ExpectObservable(s.Cold("-a-b|", ints).SkipUntil(s.Cold("-|", nil))).ToBe("----|", ints)
+		s.Flush()
+	})
+}
+
+func TestSkipLast(t *testing.T) {
+	Run(t, func(t *testing.T, s *Scheduler) {
+		s.ExpectObservable(s.Cold("-a-b-c-d|", ints).SkipLast(2)).ToBe("-----a-b|", ints)
+		s.Flush()
+	})
+}
+
+func TestDistinctUntilChanged(t *testing.T) {
+	Run(t, func(t *testing.T, s *Scheduler) {
+		s.ExpectObservable(s.Cold("-a-a-b-b-a|", ints).DistinctUntilChanged(nil)).ToBe("-a---b---a|", ints)
+		s.Flush()
+	})
+}
+
+func TestDistinctUntilKeyChanged(t *testing.T) {
+	Run(t, func(t *testing.T, s *Scheduler) {
+		obs := s.Cold("-a-c-b-d|", ints).DistinctUntilKeyChanged(func(in interface{}) interface{} {
+			return in.(int) % 2
+		})
+		s.ExpectObservable(obs).ToBe("-a---b--|", ints)
+		s.Flush()
+	})
+}
+
+func TestDistinct(t *testing.T) {
+	Run(t, func(t *testing.T, s *Scheduler) {
+		s.ExpectObservable(s.Cold("-a-b-a-c-b|", ints).Distinct(nil)).ToBe("-a-b---c--|", ints)
+		s.Flush()
+	})
+}
+
+func TestDistinctSize(t *testing.T) {
+	Run(t, func(t *testing.T, s *Scheduler) {
+		obs := s.Cold("-a-b-c-a-c|", ints).Distinct(nil, urx.DistinctOptions{Size: 2})
+		s.ExpectObservable(obs).ToBe("-a-b-c-a--|", ints)
+		s.Flush()
+	})
+}
+
+func TestDistinctTTL(t *testing.T) {
+	Run(t, func(t *testing.T, s *Scheduler) {
+		obs := s.Cold("-a-a-b---a|", ints).Distinct(nil, urx.DistinctOptions{TTL: 5 * FrameDuration, Clock: s})
+		s.ExpectObservable(obs).ToBe("-a---b---a|", ints)
+		s.Flush()
+	})
+}
+
+func TestDebounce(t *testing.T) {
+	Run(t, func(t *testing.T, s *Scheduler) {
+		obs := s.Cold("-a-b-----c-d|", ints).Debounce(3*FrameDuration, s)
+		s.ExpectObservable(obs).ToBe("------b-----(d|)", ints)
+		s.Flush()
+	})
+}
+
+func TestDebounceError(t *testing.T) {
+	Run(t, func(t *testing.T, s *Scheduler) {
+		s.ExpectObservable(s.Cold("-a-#", ints).Debounce(5*FrameDuration, s)).ToBe("---#", ints)
+		s.Flush()
+	})
+}
+
+func TestThrottleFirst(t *testing.T) {
+	Run(t, func(t *testing.T, s *Scheduler) {
+		obs := s.Cold("-a-b-c---d|", ints).ThrottleFirst(4*FrameDuration, s)
+		s.ExpectObservable(obs).ToBe("-a---c---d|", ints)
+		s.Flush()
+	})
+}
+
+func TestAudit(t *testing.T) {
+	Run(t, func(t *testing.T, s *Scheduler) {
+		obs := s.Cold("-a-b-----c-(d|)", ints).Audit(3*FrameDuration, s)
+		s.ExpectObservable(obs).ToBe("----b------(d|)", ints)
+		s.Flush()
+	})
+}
+
+func TestSample(t *testing.T) {
+	Run(t, func(t *testing.T, s *Scheduler) {
+		obs := s.Cold("-a-b---------c|", ints).Sample(4*FrameDuration, s)
+		s.ExpectObservable(obs).ToBe("----b---------|", ints)
+		s.Flush()
+	})
+}
+
+func TestSampleWith(t *testing.T) {
+	Run(t, func(t *testing.T, s *Scheduler) {
+		obs := s.Cold("-a-b-c-----d|", ints).SampleWith(s.Cold("--x---x-x-", nil))
+		s.ExpectObservable(obs).ToBe("--a---c-----|", ints)
+		s.Flush()
+	})
+}
+
+func TestBufferCount(t *testing.T) {
+	Run(t, func(t *testing.T, s *Scheduler) {
+		batches := map[string]interface{}{
+			"x": []interface{}{1, 2},
+			"y": []interface{}{3, 4},
+			"z": []interface{}{2, 3},
+			"w": []interface{}{4},
+			"v": []interface{}{3},
+		}
+		s.ExpectObservable(s.Cold("-a-b-c-(d|)", ints).BufferCount(2, 0)).ToBe("---x---(y|)", batches)
+		s.ExpectObservable(s.Cold("-a-b-c-(d|)", ints).BufferCount(2, 1)).ToBe("---x-z-(yw|)", batches)
+		s.ExpectObservable(s.Cold("-a-b-c|", ints).BufferCount(2, 2)).ToBe("---x--(v|)", batches)
+		s.Flush()
+	})
+}
+
+func TestBufferTime(t *testing.T) {
+	Run(t, func(t *testing.T, s *Scheduler) {
+		batches := map[string]interface{}{
+			"x": []interface{}{1, 2},
+			"y": []interface{}{3},
+		}
+		obs := s.Cold("-a-b-------c-|", ints).BufferTime(4*FrameDuration, s)
+		s.ExpectObservable(obs).ToBe("----x-------y|", batches)
+		s.Flush()
+	})
+}
+
+func TestBufferTimeOrCount(t *testing.T) {
+	Run(t, func(t *testing.T, s *Scheduler) {
+		batches := map[string]interface{}{
+			"x": []interface{}{1, 2},
+			"y": []interface{}{3},
+			"z": []interface{}{4},
+		}
+		obs := s.Cold("-ab--c-----(d|)", ints).BufferTimeOrCount(4*FrameDuration, 2, s)
+		s.ExpectObservable(obs).ToBe("--x---y----(z|)", batches)
+		s.Flush()
+	})
+}
+
+func TestBufferWhen(t *testing.T) {
+	Run(t, func(t *testing.T, s *Scheduler) {
+		batches := map[string]interface{}{
+			"x": []interface{}{1, 2},
+			"y": []interface{}{3, 4},
+		}
+		obs := s.Cold("-a-b--c-d|", ints).BufferWhen(s.Cold("----x|", nil))
+		s.ExpectObservable(obs).ToBe("----x----(y|)", batches)
+		s.Flush()
+	})
+}
+
+// describes each window an expectation recorded as the frame it opened at and the values it got
+func windowsOf(e *Expectation) []string {
+	var out []string
+	for _, r := range e.actual {
+		switch r.Notification.Type {
+		case urx.OnNext:
+			var values []interface{}
+			err := r.Notification.Body.(urx.Observable).ForEach(func(v interface{}) {
+				values = append(values, v)
+			})
+			out = append(out, fmt.Sprintf("%d:%v:%v", r.Frame, values, err))
+		default:
+			out = append(out, r.String())
+		}
+	}
+	return out
+}
+
+func expectWindows(t *testing.T, e *Expectation, expected ...string) {
+	t.Helper()
+	if got := windowsOf(e); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected windows %v but got %v", expected, got)
+	}
+}
+
+func TestWindowCount(t *testing.T) {
+	Run(t, func(t *testing.T, s *Scheduler) {
+		e := s.ExpectObservable(s.Cold("-a-b-c|", ints).WindowCount(2))
+		s.Flush()
+		expectWindows(t, e, "1:[1 2]:<nil>", "5:[3]:<nil>", Record{6, urx.Complete()}.String())
+	})
+}
+
+func TestWindowTime(t *testing.T) {
+	Run(t, func(t *testing.T, s *Scheduler) {
+		e := s.ExpectObservable(s.Cold("-a-b-----c-#", ints).WindowTime(4*FrameDuration, s))
+		s.Flush()
+		expectWindows(t, e, "1:[1 2]:<nil>", "9:[3]:"+ErrMarble.Error(), Record{11, urx.Error(ErrMarble)}.String())
+	})
+}
+
+func TestWindowToggle(t *testing.T) {
+	Run(t, func(t *testing.T, s *Scheduler) {
+		closing := func(interface{}) urx.Observable {
+			return s.Cold("----x", nil)
+		}
+		e := s.ExpectObservable(s.Cold("-a-b-c-d|", ints).WindowToggle(s.Cold("--o-o", nil), closing))
+		s.Flush()
+		expectWindows(t, e, "2:[2 3]:<nil>", "4:[3 4]:<nil>", Record{8, urx.Complete()}.String())
+	})
+}
+
+func TestWindowWhen(t *testing.T) {
+	Run(t, func(t *testing.T, s *Scheduler) {
+		e := s.ExpectObservable(s.Cold("-a-b--c-d|", ints).WindowWhen(s.Cold("----x|", nil)))
+		s.Flush()
+		expectWindows(t, e, "1:[1 2]:<nil>", "6:[3 4]:<nil>", Record{9, urx.Complete()}.String())
+	})
+}
+
+func TestFlatMap(t *testing.T) {
+	Run(t, func(t *testing.T, s *Scheduler) {
+		project := func(v interface{}) urx.Observable {
+			return s.Cold("-x--y|", map[string]interface{}{"x": v, "y": v.(int) * 10})
+		}
+		values := map[string]interface{}{"a": 1, "b": 2, "x": 10, "y": 20}
+		s.ExpectObservable(s.Cold("-a-b|", ints).FlatMap(project)).ToBe("--a-bx-y|", values)
+		s.Flush()
+	})
+}
+
+func TestFlatMapMaxConcurrent(t *testing.T) {
+	Run(t, func(t *testing.T, s *Scheduler) {
+		project := func(v interface{}) urx.Observable {
+			return s.Cold("-x--y|", map[string]interface{}{"x": v, "y": v.(int) * 10})
+		}
+		values := map[string]interface{}{"a": 1, "b": 2, "x": 10, "y": 20}
+		s.ExpectObservable(s.Cold("-a-b|", ints).FlatMap(project, 1)).ToBe("--a--x-b--y|", values)
+		s.Flush()
+	})
+}
+
+func TestFlatMapError(t *testing.T) {
+	Run(t, func(t *testing.T, s *Scheduler) {
+		project := func(v interface{}) urx.Observable {
+			if v.(int) == 2 {
+				return s.Cold("-#", nil)
+			}
+			return s.Cold("-a---b|", ints)
+		}
+		s.ExpectObservable(s.Cold("-a-b|", ints).FlatMap(project)).ToBe("--a-#", ints)
+		s.Flush()
+	})
+}
+
+func TestConcatMap(t *testing.T) {
+	Run(t, func(t *testing.T, s *Scheduler) {
+		project := func(v interface{}) urx.Observable {
+			return s.Cold("-x--y|", map[string]interface{}{"x": v, "y": v.(int) * 10})
+		}
+		values := map[string]interface{}{"a": 1, "b": 2, "x": 10, "y": 20}
+		s.ExpectObservable(s.Cold("-ab|", ints).ConcatMap(project)).ToBe("--a--x-b--y|", values)
+		s.Flush()
+	})
+}
+
+func TestSwitchMap(t *testing.T) {
+	Run(t, func(t *testing.T, s *Scheduler) {
+		project := func(v interface{}) urx.Observable {
+			return s.Cold("-x--y|", map[string]interface{}{"x": v, "y": v.(int) * 10})
+		}
+		values := map[string]interface{}{"a": 1, "b": 2, "y": 20}
+		s.ExpectObservable(s.Cold("-a-b|", ints).SwitchMap(project)).ToBe("--a-b--y|", values)
+		s.Flush()
+	})
+}
+
+func TestExhaustMap(t *testing.T) {
+	Run(t, func(t *testing.T, s *Scheduler) {
+		project := func(v interface{}) urx.Observable {
+			return s.Cold("-x--y|", map[string]interface{}{"x": v, "y": v.(int) * 10})
+		}
+		values := map[string]interface{}{"a": 1, "c": 3, "x": 10, "z": 30}
+		s.ExpectObservable(s.Cold("-a-b---c|", ints).ExhaustMap(project)).ToBe("--a--x--c--z|", values)
+		s.Flush()
+	})
+}
+
+func TestZip(t *testing.T) {
+	Run(t, func(t *testing.T, s *Scheduler) {
+		pairs := map[string]interface{}{
+			"x": []interface{}{1, 3},
+			"y": []interface{}{2, 4},
+		}
+		one := s.Cold("-a-b-----|", ints)
+		two := s.Cold("---c-d|", ints)
+		s.ExpectObservable(urx.Zip(one, two)).ToBe("---x-y|", pairs)
+		s.Flush()
+	})
+}
+
+func TestZipWaitsForBuffered(t *testing.T) {
+	Run(t, func(t *testing.T, s *Scheduler) {
+		pairs := map[string]interface{}{
+			"x": []interface{}{1, 3},
+			"y": []interface{}{2, 4},
+		}
+		one := s.Cold("-ab|", ints)
+		two := s.Cold("---c-d-|", ints)
+		s.ExpectObservable(urx.Zip(one, two)).ToBe("---x-(y|)", pairs)
+		s.Flush()
+	})
+}
+
+func TestCombineLatest(t *testing.T) {
+	Run(t, func(t *testing.T, s *Scheduler) {
+		sum := func(values []interface{}) interface{} {
+			return values[0].(int) + values[1].(int)
+		}
+		one := s.Cold("-a---b---|", ints)
+		two := s.Cold("---c---d|", ints)
+		values := map[string]interface{}{"w": 4, "x": 5, "y": 6}
+		s.ExpectObservable(urx.CombineLatest(sum, one, two)).ToBe("---w-x-y-|", values)
+		s.Flush()
+	})
+}
+
+func TestWithLatestFrom(t *testing.T) {
+	Run(t, func(t *testing.T, s *Scheduler) {
+		pairs := map[string]interface{}{
+			"x": []interface{}{2, 3},
+			"y": []interface{}{4, 3},
+		}
+		other := s.Cold("--c|", ints)
+		s.ExpectObservable(s.Cold("-a-b-d|", ints).WithLatestFrom(other)).ToBe("---x-y|", pairs)
+		s.Flush()
+	})
+}
+
+func TestDefer(t *testing.T) {
+	Run(t, func(t *testing.T, s *Scheduler) {
+		obs := urx.Defer(func() urx.Observable {
+			return s.Cold("-a-b|", ints)
+		})
+		s.ExpectObservable(obs).ToBe("-a-b|", ints)
+		s.Flush()
+	})
+}
@@ -0,0 +1,230 @@
+// Package urxtest runs observables against a virtual clock and checks what they produce
+// against marble diagrams.
+package urxtest
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"testing/synctest"
+
+	"github.com/Spectonic/urx"
+)
+
+type action struct {
+	frame int
+	seq   int
+	f     func()
+}
+
+// A Scheduler owns a virtual clock measured in frames. Actions scheduled for a frame run
+// when Flush reaches it, and the clock only moves on once every goroutine of the pipelines
+// under test is blocked, so the frame at which each notification arrives is deterministic.
+// That is decided by testing/synctest, so a Scheduler must be used inside a synctest bubble,
+// which Run sets up, and a goroutine which never blocks keeps Flush waiting.
+type Scheduler struct {
+	// the frame after which Flush stops running actions
+	MaxFrames int
+
+	t            testing.TB
+	frame        int64
+	mutex        sync.Mutex
+	seq          int
+	actions      []action
+	expectations []*Expectation
+}
+
+// creates a Scheduler for use inside the synctest bubble the caller is running in
+func NewScheduler(t testing.TB) *Scheduler {
+	return &Scheduler{t: t, MaxFrames: 750}
+}
+
+// Run calls f with a new Scheduler inside a synctest bubble, waiting for every goroutine the
+// test started to exit once f returns
+func Run(t *testing.T, f func(t *testing.T, s *Scheduler)) {
+	t.Helper()
+	synctest.Test(t, func(t *testing.T) {
+		f(t, NewScheduler(t))
+	})
+}
+
+// the current virtual frame
+func (s *Scheduler) Frame() int {
+	return int(atomic.LoadInt64(&s.frame))
+}
+
+func (s *Scheduler) schedule(frame int, f func()) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.seq++
+	s.actions = append(s.actions, action{frame, s.seq, f})
+}
+
+// Schedule runs the task during the current frame, which makes the Scheduler usable wherever a
+// urx.Scheduler is. Tasks run on the goroutine calling Flush, so they must not block on anything
+// but the pipelines under test.
+func (s *Scheduler) Schedule(task func()) {
+	s.schedule(s.Frame(), task)
+}
+
+// Cold creates an observable which plays the marbles from the frame each subscription starts at
+func (s *Scheduler) Cold(marbles string, values map[string]interface{}) urx.Observable {
+	records, _ := ParseMarbles(marbles, values)
+	return urx.Create(func(sub urx.Subscriber) {
+		start := s.Frame()
+		for _, r := range records {
+			n := r.Notification
+			s.schedule(start+r.Frame, func() {
+				if sub.IsSubscribed() {
+					sub.Notify(n)
+				}
+			})
+		}
+	})
+}
+
+// Hot creates an observable which plays the marbles regardless of subscriptions, with the
+// subscription point (^) at frame 0. Notifications before the subscription point are dropped.
+func (s *Scheduler) Hot(marbles string, values map[string]interface{}) urx.Observable {
+	records, _ := ParseMarbles(marbles, values)
+	subj := urx.NewPublishSubject()
+	for _, r := range records {
+		if r.Frame < 0 {
+			continue
+		}
+		n := r.Notification
+		s.schedule(r.Frame, func() {
+			subj.Post(n)
+		})
+	}
+	return subj.AsObservable()
+}
+
+// ExpectObservable subscribes to obs when Flush is called and records everything it produces
+func (s *Scheduler) ExpectObservable(obs urx.Observable) *Expectation {
+	e := &Expectation{s: s, obs: obs}
+	s.mutex.Lock()
+	s.expectations = append(s.expectations, e)
+	s.mutex.Unlock()
+	return e
+}
+
+// Flush subscribes to every expectation, runs all scheduled actions in frame order and then
+// reports any expectation which was not met
+func (s *Scheduler) Flush() {
+	s.t.Helper()
+	s.mutex.Lock()
+	expectations := s.expectations
+	s.expectations = nil
+	s.mutex.Unlock()
+
+	for _, e := range expectations {
+		e.subscribe()
+	}
+	s.settle()
+	for {
+		frame, ok := s.nextFrame()
+		if !ok || frame > s.MaxFrames {
+			break
+		}
+		if frame > s.Frame() {
+			atomic.StoreInt64(&s.frame, int64(frame))
+		}
+		for _, a := range s.take(frame) {
+			a.f()
+		}
+		s.settle()
+	}
+	for _, e := range expectations {
+		if !e.verify() {
+			s.t.Errorf("expected %s but got %s", formatRecords(e.expected), formatRecords(e.actual))
+		}
+	}
+}
+
+func (s *Scheduler) nextFrame() (frame int, ok bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for i, a := range s.actions {
+		if i == 0 || a.frame < frame {
+			frame = a.frame
+		}
+	}
+	return frame, len(s.actions) > 0
+}
+
+// removes and returns the actions due at or before the frame, in the order they were scheduled
+func (s *Scheduler) take(frame int) (due []action) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	rest := s.actions[:0]
+	for _, a := range s.actions {
+		if a.frame <= frame {
+			due = append(due, a)
+		} else {
+			rest = append(rest, a)
+		}
+	}
+	s.actions = rest
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].seq < due[j].seq
+	})
+	return
+}
+
+// waits until every other goroutine in the bubble is blocked, so the pipelines have done
+// everything they can before the clock moves on
+func (s *Scheduler) settle() {
+	synctest.Wait()
+}
+
+type Expectation struct {
+	s        *Scheduler
+	obs      urx.Observable
+	sub      urx.Subscription
+	expected []Record
+	checked  bool
+	mutex    sync.Mutex
+	actual   []Record
+	stopped  bool
+}
+
+// ToBe sets the marbles (and their values) the observable is expected to produce
+func (e *Expectation) ToBe(marbles string, values map[string]interface{}) {
+	e.expected, _ = ParseMarbles(marbles, values)
+	e.checked = true
+}
+
+func (e *Expectation) subscribe() {
+	e.sub = e.obs.Subscribe()
+	events := e.sub.Events()
+	go func() {
+		for n := range events {
+			e.record(n)
+		}
+	}()
+}
+
+func (e *Expectation) record(n urx.Notification) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	if e.stopped || n.Type == urx.OnStart {
+		return
+	}
+	e.actual = append(e.actual, Record{Frame: e.s.Frame(), Notification: n})
+	if n.Type == urx.OnError || n.Type == urx.OnComplete {
+		e.stopped = true
+	}
+}
+
+// stops recording and reports whether the expectation was met
+func (e *Expectation) verify() bool {
+	e.mutex.Lock()
+	e.stopped = true
+	e.mutex.Unlock()
+	e.sub.Unsubscribe()
+
+	return !e.checked || reflect.DeepEqual(e.actual, e.expected)
+}
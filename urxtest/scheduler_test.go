@@ -0,0 +1,135 @@
+package urxtest
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/Spectonic/urx"
+)
+
+var ints = map[string]interface{}{"a": 1, "b": 2, "c": 3, "d": 4}
+
+func TestParseMarbles(t *testing.T) {
+	records, sub := ParseMarbles("-a-^b-(c|)", ints)
+	expected := []Record{
+		{-2, urx.Next(1)},
+		{1, urx.Next(2)},
+		{3, urx.Next(3)},
+		{3, urx.Complete()},
+	}
+	if sub != 3 || !reflect.DeepEqual(records, expected) {
+		t.Errorf("got %s with subscription at %d", formatRecords(records), sub)
+	}
+}
+
+func TestMap(t *testing.T) {
+	Run(t, func(t *testing.T, s *Scheduler) {
+		obs := s.Cold("-a-b-(c|)", ints).Map(func(in interface{}) interface{} {
+			return in.(int) * 10
+		})
+		s.ExpectObservable(obs).ToBe("-a-b-(c|)", map[string]interface{}{"a": 10, "b": 20, "c": 30})
+		s.Flush()
+	})
+}
+
+func TestFilter(t *testing.T) {
+	Run(t, func(t *testing.T, s *Scheduler) {
+		obs := s.Cold("-a-b-c-d|", ints).Filter(func(in interface{}) bool {
+			return in.(int)%2 == 0
+		})
+		s.ExpectObservable(obs).ToBe("---b---d|", ints)
+		s.Flush()
+	})
+}
+
+func TestBuffered(t *testing.T) {
+	Run(t, func(t *testing.T, s *Scheduler) {
+		s.ExpectObservable(s.Cold("-a-b-(c|)", ints).Buffered(2)).ToBe("-a-b-(c|)", ints)
+		s.Flush()
+	})
+}
+
+func TestLift(t *testing.T) {
+	Run(t, func(t *testing.T, s *Scheduler) {
+		obs := s.Cold("-a-b-c|", ints).Lift(urx.FunctionOperator(func(sub urx.Subscriber, n urx.Notification) {
+			if n.Type == urx.OnNext && n.Body.(int) == 2 {
+				sub.Notify(urx.Error(ErrMarble))
+				return
+			}
+			sub.Notify(n)
+		}))
+		s.ExpectObservable(obs).ToBe("-a-#", ints)
+		s.Flush()
+	})
+}
+
+func TestMerge(t *testing.T) {
+	Run(t, func(t *testing.T, s *Scheduler) {
+		one := s.Cold("-a---b|", ints)
+		two := s.Cold("--c-d|", ints)
+		s.ExpectObservable(urx.Merge(one, two)).ToBe("-ac-db|", ints)
+		s.Flush()
+	})
+}
+
+func TestHot(t *testing.T) {
+	Run(t, func(t *testing.T, s *Scheduler) {
+		s.ExpectObservable(s.Hot("--a-^-b-c|", ints)).ToBe("--b-c|", ints)
+		s.Flush()
+	})
+}
+
+func TestError(t *testing.T) {
+	Run(t, func(t *testing.T, s *Scheduler) {
+		err := fmt.Errorf("boom")
+		values := map[string]interface{}{"a": 1, "#": err}
+		s.ExpectObservable(s.Cold("-a--#", values)).ToBe("-a--#", values)
+		s.Flush()
+	})
+}
+
+func TestObserveOn(t *testing.T) {
+	Run(t, func(t *testing.T, s *Scheduler) {
+		s.ExpectObservable(s.Cold("-a-b-(c|)", ints).ObserveOn(s)).ToBe("-a-b-(c|)", ints)
+		s.Flush()
+	})
+}
+
+func TestSubscribeOn(t *testing.T) {
+	Run(t, func(t *testing.T, s *Scheduler) {
+		obs := urx.Create(func(sub urx.Subscriber) {
+			sub.Notify(urx.Next(s.Frame()))
+			sub.Notify(urx.Complete())
+		}).SubscribeOn(s)
+		s.ExpectObservable(obs).ToBe("(a|)", map[string]interface{}{"a": 0})
+		s.Flush()
+	})
+}
+
+func TestTimer(t *testing.T) {
+	Run(t, func(t *testing.T, s *Scheduler) {
+		s.ExpectObservable(urx.Timer(2*FrameDuration, s)).ToBe("--(a|)", map[string]interface{}{"a": 0})
+		s.Flush()
+	})
+}
+
+func TestTimerPeriodic(t *testing.T) {
+	Run(t, func(t *testing.T, s *Scheduler) {
+		s.MaxFrames = 10
+		s.ExpectObservable(urx.TimerPeriodic(2*FrameDuration, 3*FrameDuration, s)).ToBe("--a--b--c-", map[string]interface{}{"a": 0, "b": 1, "c": 2})
+		s.Flush()
+	})
+}
+
+func TestIntervalStopsTicker(t *testing.T) {
+	Run(t, func(t *testing.T, s *Scheduler) {
+		obs := urx.Interval(2*FrameDuration, s).Take(2)
+		s.ExpectObservable(obs).ToBe("--a-(b|)", map[string]interface{}{"a": 0, "b": 1})
+		s.Flush()
+		//a running ticker always has its next tick scheduled
+		if n := len(s.actions); n > 0 {
+			t.Errorf("the ticker was not stopped, %d actions are still scheduled", n)
+		}
+	})
+}
@@ -0,0 +1,231 @@
+package urx
+
+import "time"
+
+// the windows a window operator has open, owned by the operator's goroutine. Each window is a replay
+// subject, so subscribing to it late still gets every value it has been given
+type windows struct {
+	sub    Subscriber
+	open   map[int]Subject
+	nextID int
+}
+
+func newWindows(sub Subscriber) *windows {
+	return &windows{sub: sub, open: make(map[int]Subject)}
+}
+
+// opens a new window, emitting it, and returns its id
+func (w *windows) openWindow() int {
+	id := w.nextID
+	w.nextID++
+	window := NewReplaySubject(ReplayOptions{})
+	w.open[id] = window
+	w.sub.Notify(Next(window.AsObservable()))
+	return id
+}
+
+// gives the value to every open window
+func (w *windows) next(v interface{}) {
+	for _, window := range w.open {
+		window.Next(v)
+	}
+}
+
+func (w *windows) closeWindow(id int) {
+	if window, ok := w.open[id]; ok {
+		window.Complete()
+		delete(w.open, id)
+	}
+}
+
+// ends every open window with the terminal notification
+func (w *windows) terminate(n Notification) {
+	for id, window := range w.open {
+		window.Post(n)
+		delete(w.open, id)
+	}
+}
+
+// ends the open windows and the output with how the source terminated
+func (w *windows) finish(n Notification) {
+	w.terminate(n)
+	w.sub.Notify(n)
+}
+
+// emits the values in windows of size, each an Observable. A window is opened by the first value
+// after the previous one completes. Panics if size is not positive
+func (o bObservable) WindowCount(size int) Observable {
+	if size <= 0 {
+		panic("urx: WindowCount needs a positive size")
+	}
+	return Create(func(sub Subscriber) {
+		inner := subscribeFor(o, sub)
+		ws := newWindows(sub)
+		defer ws.terminate(Complete())
+		count := 0
+		for n := range inner.Events() {
+			switch n.Type {
+			case OnNext:
+				if len(ws.open) == 0 {
+					ws.openWindow()
+				}
+				ws.next(n.Body)
+				count++
+				if count >= size {
+					ws.terminate(Complete())
+					count = 0
+				}
+			case OnComplete, OnError:
+				ws.finish(n)
+				return
+			}
+		}
+	})
+}
+
+// emits the values which arrived in each period of d in a window, each an Observable.
+// A window is opened by the first value in a period, so there are no empty windows. Panics if d is not positive
+func (o bObservable) WindowTime(d time.Duration, clock ...Clock) Observable {
+	if d <= 0 {
+		panic("urx: WindowTime needs a positive period")
+	}
+	c := clockOf(clock)
+	return Create(func(sub Subscriber) {
+		inner := subscribeFor(o, sub)
+		ws := newWindows(sub)
+		defer ws.terminate(Complete())
+		timer := &restartableTimer{clock: c}
+		defer timer.stop()
+		timer.start(d)
+		for {
+			select {
+			case n, ok := <-inner.Events():
+				switch {
+				case !ok:
+					return
+				case n.Type == OnNext:
+					if len(ws.open) == 0 {
+						ws.openWindow()
+					}
+					ws.next(n.Body)
+				case n.Type == OnComplete || n.Type == OnError:
+					ws.finish(n)
+					return
+				}
+			case <-timer.C():
+				ws.terminate(Complete())
+				timer.start(d)
+			}
+		}
+	})
+}
+
+// opens a window, an Observable, whenever openings emits, which gets values until the observable returned
+// by closing for that opening emits or completes. Windows may overlap
+func (o bObservable) WindowToggle(openings Observable, closing func(interface{}) Observable) Observable {
+	type windowClose struct {
+		id int
+		n  Notification
+	}
+	return Create(func(sub Subscriber) {
+		inner := subscribeFor(o, sub)
+		opens := subscribeFor(openings, sub).Events()
+		ws := newWindows(sub)
+		defer ws.terminate(Complete())
+		closes := make(chan windowClose)
+		done := make(chan interface{})
+		defer close(done)
+		//the closer is only subscribed to until its window closes, rather than for as long as sub is
+		watchClosing := func(id int, closer Observable) {
+			closings := closer.Subscribe()
+			defer closings.Unsubscribe()
+			events := closings.Events()
+			for {
+				select {
+				case n, ok := <-events:
+					if !ok {
+						return
+					}
+					if n.Type == OnStart {
+						continue
+					}
+					select {
+					case closes <- windowClose{id, n}:
+					case <-done:
+					}
+					return
+				case <-done:
+					return
+				}
+			}
+		}
+		for {
+			select {
+			case n, ok := <-inner.Events():
+				switch {
+				case !ok:
+					return
+				case n.Type == OnNext:
+					ws.next(n.Body)
+				case n.Type == OnComplete || n.Type == OnError:
+					ws.finish(n)
+					return
+				}
+			case n, ok := <-opens:
+				switch {
+				case !ok || n.Type == OnComplete:
+					opens = nil
+				case n.Type == OnNext:
+					go watchClosing(ws.openWindow(), closing(n.Body))
+				case n.Type == OnError:
+					ws.finish(n)
+					return
+				}
+			case c := <-closes:
+				if c.n.Type == OnError {
+					ws.finish(c.n)
+					return
+				}
+				ws.closeWindow(c.id)
+			}
+		}
+	})
+}
+
+// emits the values which arrived before each value from closing in a window, each an Observable.
+// A window is opened by the first value after the previous one closes, so there are no empty windows
+func (o bObservable) WindowWhen(closing Observable) Observable {
+	return Create(func(sub Subscriber) {
+		inner := subscribeFor(o, sub)
+		closings := subscribeFor(closing, sub).Events()
+		ws := newWindows(sub)
+		defer ws.terminate(Complete())
+		for {
+			select {
+			case n, ok := <-inner.Events():
+				switch {
+				case !ok:
+					return
+				case n.Type == OnNext:
+					if len(ws.open) == 0 {
+						ws.openWindow()
+					}
+					ws.next(n.Body)
+				case n.Type == OnComplete || n.Type == OnError:
+					ws.finish(n)
+					return
+				}
+			case n, ok := <-closings:
+				switch {
+				case !ok || n.Type == OnComplete:
+					closings = nil
+				case n.Type == OnNext:
+					ws.terminate(Complete())
+				case n.Type == OnError:
+					ws.finish(n)
+					return
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,21 @@
+package urx
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWindowArguments(t *testing.T) {
+	rejects := func(name string, f func()) {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("%s was accepted", name)
+			}
+		}()
+		f()
+	}
+	rejects("WindowCount(0)", func() { Just(1).WindowCount(0) })
+	rejects("WindowCount(-1)", func() { Just(1).WindowCount(-1) })
+	rejects("WindowTime(0)", func() { Just(1).WindowTime(0) })
+	rejects("WindowTime(-1s)", func() { Just(1).WindowTime(-time.Second) })
+}